@@ -0,0 +1,80 @@
+package span
+
+// Set operations on multispans. Apart from Union, which normalizes its
+// result from anything, the inputs must already be normalized.
+
+// Union is every integer in either multispan
+func (ms Multispan) Union(t Multispan) Multispan {
+
+	all := make(Multispan, 0, len(ms)+len(t))
+	all = append(all, ms...)
+	all = append(all, t...)
+
+	return all.Normalize()
+}
+
+// Intersect is every integer in both multispans
+func (ms Multispan) Intersect(t Multispan) Multispan {
+
+	out := NewMultiSpan(0)
+
+	i, j := 0, 0
+	for i < len(ms) && j < len(t) {
+
+		if o, err := ms[i].Overlap(t[j]); err == nil {
+			out = append(out, o)
+		}
+
+		// drop whichever span finishes first
+		if ms[i].End < t[j].End {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return out
+}
+
+// Subtract is every integer in ms but not in t
+func (ms Multispan) Subtract(t Multispan) Multispan {
+
+	out := NewMultiSpan(len(ms))
+
+	j := 0
+	for _, s := range ms {
+
+		// skip spans of t entirely before s
+		for j < len(t) && t[j].End < s.Start {
+			j++
+		}
+
+		cur := s
+		open := true
+
+		for k := j; k < len(t) && t[k].Start <= cur.End; k++ {
+
+			if t[k].Start > cur.Start {
+				out = append(out, Span{Start: cur.Start, End: t[k].Start - 1})
+			}
+
+			if t[k].End >= cur.End {
+				open = false
+				break
+			}
+
+			cur.Start = t[k].End + 1
+		}
+
+		if open {
+			out = append(out, cur)
+		}
+	}
+
+	return out
+}
+
+// Complement is every integer not in ms
+func (ms Multispan) Complement() Multispan {
+	return Multispan{Unbounded}.Subtract(ms)
+}
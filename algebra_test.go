@@ -0,0 +1,45 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestSetOperations(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given two normalized multispans", t, func() {
+		a := Multispan{{1, 10}, {20, 30}}
+		b := Multispan{{5, 22}, {28, 40}}
+
+		Convey("Union() should merge them", func() {
+			So(a.Union(b), ShouldResemble, Multispan{{1, 40}})
+		})
+
+		Convey("Intersect() should keep what they share", func() {
+			So(a.Intersect(b), ShouldResemble, Multispan{{5, 10}, {20, 22}, {28, 30}})
+		})
+
+		Convey("Subtract() should remove the other's integers", func() {
+			So(a.Subtract(b), ShouldResemble, Multispan{{1, 4}, {23, 27}})
+			So(b.Subtract(a), ShouldResemble, Multispan{{11, 19}, {31, 40}})
+		})
+
+		Convey("Complement() should run out to infinity", func() {
+			So(a.Complement(), ShouldResemble, Multispan{UpTo(0), {11, 19}, From(31)})
+			So(a.Complement().Complement(), ShouldResemble, a)
+			So(NewMultiSpan(0).Complement(), ShouldResemble, Multispan{Unbounded})
+			So(Multispan{Unbounded}.Complement(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given multispans with open ends", t, func() {
+		a := Multispan{UpTo(10), From(20)}
+		b := Multispan{{5, 25}}
+
+		Convey("The operations should treat the ends as infinite", func() {
+			So(a.Intersect(b), ShouldResemble, Multispan{{5, 10}, {20, 25}})
+			So(a.Subtract(b), ShouldResemble, Multispan{UpTo(4), From(26)})
+			So(b.Subtract(a), ShouldResemble, Multispan{{11, 19}})
+			So(a.Union(b), ShouldResemble, Multispan{Unbounded})
+		})
+	})
+}
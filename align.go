@@ -0,0 +1,130 @@
+package span
+
+import (
+	"errors"
+)
+
+var ErrNoBlock = errors.New("Span holds no whole block")
+
+// Alignment chooses which way Multispan.Align rounds
+type Alignment int
+
+const (
+	Outward Alignment = iota // grow to cover every block touched
+	Inward                   // shrink to the whole blocks inside
+)
+
+// AlignOut grows the span to start and end on block boundaries, so it
+// covers every block it touches
+func (s Span) AlignOut(block int) (Span, error) {
+	if block < 1 {
+		return Zero, ErrInvalidSize
+	}
+
+	if s.Start != NegInf {
+		s.Start = floorDiv(s.Start, block) * block
+	}
+
+	if s.End != PosInf {
+		s.End = (floorDiv(s.End, block)+1)*block - 1
+	}
+
+	return s, nil
+}
+
+// AlignIn shrinks the span to the whole blocks inside it
+func (s Span) AlignIn(block int) (Span, error) {
+	if block < 1 {
+		return Zero, ErrInvalidSize
+	}
+
+	if s.Start != NegInf {
+		s.Start = -floorDiv(-s.Start, block) * block
+	}
+
+	if s.End != PosInf {
+		s.End = floorDiv(s.End+1, block)*block - 1
+	}
+
+	if s.Start > s.End {
+		return Zero, ErrNoBlock
+	}
+
+	return s, nil
+}
+
+// Blocks is the span of block indexes the span touches. Block i covers
+// i*block to (i+1)*block-1.
+func (s Span) Blocks(block int) (Span, error) {
+	if block < 1 {
+		return Zero, ErrInvalidSize
+	}
+
+	if s.Start != NegInf {
+		s.Start = floorDiv(s.Start, block)
+	}
+
+	if s.End != PosInf {
+		s.End = floorDiv(s.End, block)
+	}
+
+	return s, nil
+}
+
+// Align rounds every span to block boundaries and normalizes. Inward
+// alignment drops spans that hold no whole block.
+func (ms Multispan) Align(block int, mode Alignment) (Multispan, error) {
+
+	out := NewMultiSpan(len(ms))
+
+	for _, s := range ms {
+
+		var t Span
+		var err error
+
+		if mode == Inward {
+			t, err = s.AlignIn(block)
+			if err == ErrNoBlock {
+				continue
+			}
+		} else {
+			t, err = s.AlignOut(block)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, t)
+	}
+
+	return out.Normalize(), nil
+}
+
+// Blocks is the normalized multispan of block indexes touched
+func (ms Multispan) Blocks(block int) (Multispan, error) {
+
+	out := make(Multispan, len(ms))
+
+	for i, s := range ms {
+
+		t, err := s.Blocks(block)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = t
+	}
+
+	return out.Normalize(), nil
+}
+
+// floorDiv divides rounding towards negative infinity
+func floorDiv(n, d int) int {
+	q := n / d
+	if n%d != 0 && n < 0 {
+		q--
+	}
+
+	return q
+}
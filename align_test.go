@@ -0,0 +1,69 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestAlign(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given byte ranges and a block size of 4", t, func() {
+
+		Convey("AlignOut() should grow to block boundaries", func() {
+			s, err := Span{5, 9}.AlignOut(4)
+			So(err, ShouldBeNil)
+			So(s, ShouldResemble, Span{4, 11})
+
+			s, _ = Span{8, 11}.AlignOut(4)
+			So(s, ShouldResemble, Span{8, 11})
+
+			s, _ = Span{-5, -2}.AlignOut(4)
+			So(s, ShouldResemble, Span{-8, -1})
+
+			s, _ = From(5).AlignOut(4)
+			So(s, ShouldResemble, From(4))
+
+			_, err = Span{1, 2}.AlignOut(0)
+			So(err, ShouldEqual, ErrInvalidSize)
+		})
+
+		Convey("AlignIn() should shrink to whole blocks", func() {
+			s, err := Span{3, 16}.AlignIn(4)
+			So(err, ShouldBeNil)
+			So(s, ShouldResemble, Span{4, 15})
+
+			s, _ = Span{-7, 3}.AlignIn(4)
+			So(s, ShouldResemble, Span{-4, 3})
+
+			_, err = Span{5, 9}.AlignIn(4)
+			So(err, ShouldEqual, ErrNoBlock)
+		})
+
+		Convey("Blocks() should list the block indexes touched", func() {
+			b, err := Span{5, 9}.Blocks(4)
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, Span{1, 2})
+
+			b, _ = Span{-1, 0}.Blocks(4)
+			So(b, ShouldResemble, Span{-1, 0})
+
+			bs, err := Multispan{{0, 1}, {2, 3}, {9, 9}}.Blocks(4)
+			So(err, ShouldBeNil)
+			So(bs, ShouldResemble, Multispan{{0, 0}, {2, 2}})
+		})
+
+		Convey("Align() should round every span and renormalize", func() {
+			ms := Multispan{{1, 2}, {6, 9}, {20, 22}}
+
+			out, err := ms.Align(4, Outward)
+			So(err, ShouldBeNil)
+			So(out, ShouldResemble, Multispan{{0, 3}, {4, 11}, {20, 23}})
+
+			in, err := Multispan{{1, 9}, {13, 30}}.Align(4, Inward)
+			So(err, ShouldBeNil)
+			So(in, ShouldResemble, Multispan{{4, 7}, {16, 27}})
+
+			in, _ = ms.Align(4, Inward)
+			So(in, ShouldBeEmpty)
+		})
+	})
+}
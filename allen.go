@@ -0,0 +1,246 @@
+package span
+
+// Allen's interval algebra. A closed integer span [a, b] behaves exactly
+// like the real interval [a, b+1), so the 13 relations stay jointly
+// exhaustive and pairwise disjoint: Meets means the spans are adjacent
+// (s.End+1 == t.Start), and a shared integer is already an overlap.
+
+type Relation uint8
+
+const (
+	Before Relation = iota
+	Meets
+	Overlaps
+	Starts
+	During
+	Finishes
+	Equals
+	FinishedBy
+	Contains
+	StartedBy
+	OverlappedBy
+	MetBy
+	After
+)
+
+var relationNames = [...]string{
+	"Before", "Meets", "Overlaps", "Starts", "During", "Finishes", "Equals",
+	"FinishedBy", "Contains", "StartedBy", "OverlappedBy", "MetBy", "After",
+}
+
+func (r Relation) String() string {
+	if int(r) < len(relationNames) {
+		return relationNames[r]
+	}
+
+	return "Relation(?)"
+}
+
+// Inverse is the relation seen from the other span, so if s.Relate(t) is
+// r then t.Relate(s) is r.Inverse()
+func (r Relation) Inverse() Relation {
+	return After - r
+}
+
+// Relate returns how s lies relative to t
+func (s Span) Relate(t Span) Relation {
+
+	switch {
+	case s.End < t.Start:
+		if s.End == t.Start-1 {
+			return Meets
+		}
+		return Before
+	case t.End < s.Start:
+		if t.End == s.Start-1 {
+			return MetBy
+		}
+		return After
+	case s.Start == t.Start && s.End == t.End:
+		return Equals
+	case s.Start == t.Start && s.End < t.End:
+		return Starts
+	case s.Start == t.Start:
+		return StartedBy
+	case s.End == t.End && s.Start > t.Start:
+		return Finishes
+	case s.End == t.End:
+		return FinishedBy
+	case s.Start > t.Start && s.End < t.End:
+		return During
+	case s.Start < t.Start && s.End > t.End:
+		return Contains
+	case s.Start < t.Start:
+		return Overlaps
+	}
+
+	return OverlappedBy
+}
+
+// RelationSet is a disjunction of relations, one bit per Relation
+type RelationSet uint16
+
+// AllRelations is the unconstrained relation set
+const AllRelations RelationSet = 1<<13 - 1
+
+func NewRelationSet(rs ...Relation) RelationSet {
+
+	var set RelationSet
+
+	for _, r := range rs {
+		set |= 1 << r
+	}
+
+	return set
+}
+
+func (set RelationSet) Has(r Relation) bool {
+	return set&(1<<r) != 0
+}
+
+func (set RelationSet) Relations() []Relation {
+
+	rs := make([]Relation, 0, 13)
+
+	for r := Before; r <= After; r++ {
+		if set.Has(r) {
+			rs = append(rs, r)
+		}
+	}
+
+	return rs
+}
+
+func (set RelationSet) Inverse() RelationSet {
+
+	var inv RelationSet
+
+	for r := Before; r <= After; r++ {
+		if set.Has(r) {
+			inv |= 1 << r.Inverse()
+		}
+	}
+
+	return inv
+}
+
+// Compose returns the relations possible between a and c when a r1 b and
+// b r2 c
+func Compose(r1, r2 Relation) RelationSet {
+	return composition[r1][r2]
+}
+
+// ComposeSets is Compose over every pair of members
+func ComposeSets(s1, s2 RelationSet) RelationSet {
+
+	var set RelationSet
+
+	for r1 := Before; r1 <= After; r1++ {
+		if !s1.Has(r1) {
+			continue
+		}
+		for r2 := Before; r2 <= After; r2++ {
+			if s2.Has(r2) {
+				set |= composition[r1][r2]
+			}
+		}
+	}
+
+	return set
+}
+
+// The composition table. Every arrangement of three intervals needs at
+// most six distinct endpoints, so enumerating all spans over a handful of
+// integers reaches every entry of Allen's table.
+var composition [13][13]RelationSet
+
+func init() {
+
+	const n = 7
+
+	spans := make([]Span, 0, n*(n+1)/2)
+	for a := 0; a < n; a++ {
+		for b := a; b < n; b++ {
+			spans = append(spans, Span{a, b})
+		}
+	}
+
+	for _, a := range spans {
+		for _, b := range spans {
+			ab := a.Relate(b)
+			for _, c := range spans {
+				composition[ab][b.Relate(c)] |= 1 << a.Relate(c)
+			}
+		}
+	}
+}
+
+// Network is a qualitative constraint network over n spans, holding the
+// allowed relations between every pair.
+type Network struct {
+	n int
+	c [][]RelationSet
+}
+
+// NewNetwork returns a network over n spans with no constraints
+func NewNetwork(n int) *Network {
+
+	c := make([][]RelationSet, n)
+
+	for i := range c {
+		c[i] = make([]RelationSet, n)
+		for j := range c[i] {
+			c[i][j] = AllRelations
+		}
+		c[i][i] = NewRelationSet(Equals)
+	}
+
+	return &Network{n: n, c: c}
+}
+
+// Constrain narrows the relations allowed between spans i and j
+func (nw *Network) Constrain(i, j int, set RelationSet) {
+	nw.c[i][j] &= set
+	nw.c[j][i] &= set.Inverse()
+}
+
+// Relations returns the relations currently allowed between i and j
+func (nw *Network) Relations(i, j int) RelationSet {
+	return nw.c[i][j]
+}
+
+// PathConsistency runs Allen's propagation algorithm, narrowing every
+// pair by composition through every third span. It returns false if some
+// pair is left with no possible relation, meaning the constraints cannot
+// all hold.
+func (nw *Network) PathConsistency() bool {
+
+	for changed := true; changed; {
+		changed = false
+
+		for i := 0; i < nw.n; i++ {
+			for j := 0; j < nw.n; j++ {
+				for k := 0; k < nw.n; k++ {
+
+					if k == i || k == j {
+						continue
+					}
+
+					narrowed := nw.c[i][j] & ComposeSets(nw.c[i][k], nw.c[k][j])
+					if narrowed == nw.c[i][j] {
+						continue
+					}
+
+					if narrowed == 0 {
+						return false
+					}
+
+					nw.Constrain(i, j, narrowed)
+					changed = true
+				}
+			}
+		}
+	}
+
+	return true
+}
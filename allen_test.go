@@ -0,0 +1,92 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestRelate(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a reference span", t, func() {
+		ref := Span{10, 20}
+
+		Convey("Relate() should classify every arrangement", func() {
+			cases := map[Span]Relation{
+				{1, 5}:           Before,
+				{1, 9}:           Meets,
+				{5, 12}:          Overlaps,
+				{10, 15}:         Starts,
+				{12, 18}:         During,
+				{15, 20}:         Finishes,
+				{10, 20}:         Equals,
+				{5, 20}:          FinishedBy,
+				{5, 25}:          Contains,
+				{10, 25}:         StartedBy,
+				{15, 25}:         OverlappedBy,
+				{21, 25}:         MetBy,
+				{22, 25}:         After,
+				{20, 20}:         Finishes,
+				{10, 10}:         Starts,
+				UpTo(9):          Meets,
+				From(21):         MetBy,
+				Unbounded:        Contains,
+				{NegInf, 20}:     FinishedBy,
+				{PosInf, PosInf}: After,
+			}
+
+			for s, want := range cases {
+				So(s.Relate(ref), ShouldEqual, want)
+				So(ref.Relate(s), ShouldEqual, want.Inverse())
+			}
+		})
+
+		Convey("Relations should have names", func() {
+			So(OverlappedBy.String(), ShouldEqual, "OverlappedBy")
+			So(Before.Inverse(), ShouldEqual, After)
+			So(Equals.Inverse(), ShouldEqual, Equals)
+		})
+	})
+}
+
+func TestCompose(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given Allen's composition table", t, func() {
+
+		Convey("Entries should match the published table", func() {
+			So(Compose(Before, Before), ShouldEqual, NewRelationSet(Before))
+			So(Compose(Meets, Meets), ShouldEqual, NewRelationSet(Before))
+			So(Compose(Overlaps, Overlaps), ShouldEqual, NewRelationSet(Before, Meets, Overlaps))
+			So(Compose(Starts, Contains), ShouldEqual, NewRelationSet(Before, Meets, Overlaps, FinishedBy, Contains))
+			So(Compose(During, Contains), ShouldEqual, AllRelations)
+			So(Compose(Equals, During), ShouldEqual, NewRelationSet(During))
+			So(ComposeSets(NewRelationSet(Before, Meets), NewRelationSet(Meets)), ShouldEqual, NewRelationSet(Before))
+			So(NewRelationSet(Starts, After).Relations(), ShouldResemble, []Relation{Starts, After})
+		})
+	})
+}
+
+func TestNetwork(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given constraint networks over three spans", t, func() {
+
+		Convey("A consistent network should be narrowed", func() {
+			nw := NewNetwork(3)
+			nw.Constrain(0, 1, NewRelationSet(Meets))
+			nw.Constrain(1, 2, NewRelationSet(Meets))
+
+			So(nw.PathConsistency(), ShouldBeTrue)
+			So(nw.Relations(0, 2), ShouldEqual, NewRelationSet(Before))
+			So(nw.Relations(2, 0), ShouldEqual, NewRelationSet(After))
+		})
+
+		Convey("An inconsistent network should be rejected", func() {
+			nw := NewNetwork(3)
+			nw.Constrain(0, 1, NewRelationSet(Before))
+			nw.Constrain(1, 2, NewRelationSet(Before))
+			nw.Constrain(2, 0, NewRelationSet(Before))
+
+			So(nw.PathConsistency(), ShouldBeFalse)
+		})
+	})
+}
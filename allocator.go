@@ -0,0 +1,267 @@
+package span
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrExhausted = errors.New("No free range is large enough")
+var ErrInUse = errors.New("Range is already allocated")
+var ErrDoubleFree = errors.New("Range is already free")
+var ErrOutOfPool = errors.New("Range is outside the pool")
+
+// FitPolicy chooses which free range AllocN takes from
+type FitPolicy int
+
+const (
+	FirstFit FitPolicy = iota // lowest free range that fits
+	BestFit                   // smallest free range that fits
+	NextFit                   // first fit, starting after the last allocation
+)
+
+// Allocator hands out integers, such as ports or IDs, from a pool
+type Allocator struct {
+	pool   Multispan
+	free   Multispan
+	policy FitPolicy
+	cursor int
+}
+
+// AllocStats summarises an allocator's pool
+type AllocStats struct {
+	Total       int
+	Used        int
+	Free        int
+	LargestFree int
+	FreeRanges  int
+}
+
+// Utilization is the fraction of the pool in use
+func (st AllocStats) Utilization() float64 {
+	if st.Total == 0 {
+		return 0
+	}
+
+	return float64(st.Used) / float64(st.Total)
+}
+
+// NewAllocator manages the bounded pool, which starts out entirely free
+func NewAllocator(pool Multispan, policy FitPolicy) (*Allocator, error) {
+
+	pool = append(Multispan{}, pool...).NormalizeWithin(1)
+
+	if pool.Size() == PosInf {
+		return nil, ErrUnbounded
+	}
+
+	a := &Allocator{
+		pool:   pool,
+		free:   append(Multispan{}, pool...),
+		policy: policy,
+	}
+
+	if len(pool) > 0 {
+		a.cursor = pool[0].Start
+	}
+
+	return a, nil
+}
+
+// Alloc takes a single integer
+func (a *Allocator) Alloc() (int, error) {
+
+	s, err := a.AllocN(1)
+
+	return s.Start, err
+}
+
+// AllocN takes n contiguous integers, chosen by the allocator's policy
+func (a *Allocator) AllocN(n int) (Span, error) {
+
+	if n < 1 {
+		return Zero, ErrInvalidSize
+	}
+
+	var got Span
+	var err error
+
+	switch a.policy {
+	case BestFit:
+		got, err = a.bestFit(n)
+	case NextFit:
+		got, err = a.nextFit(n)
+	default:
+		got, err = a.firstFit(n, NegInf)
+	}
+
+	if err != nil {
+		return Zero, err
+	}
+
+	a.take(got)
+
+	return got, nil
+}
+
+// AllocSpecific takes exactly s, which must be in the pool and entirely
+// free
+func (a *Allocator) AllocSpecific(s Span) error {
+
+	s = s.Normalize()
+
+	if err := a.inPool(s); err != nil {
+		return err
+	}
+
+	if free := a.free.Intersect(Multispan{s}); len(free) != 1 || free[0] != s {
+		return ErrInUse
+	}
+
+	a.take(s)
+
+	return nil
+}
+
+// Free returns s to the pool. Every integer in it must be allocated, so
+// freeing anything twice fails and leaves the allocator unchanged.
+func (a *Allocator) Free(s Span) error {
+
+	s = s.Normalize()
+
+	if err := a.inPool(s); err != nil {
+		return err
+	}
+
+	if len(a.free.Intersect(Multispan{s})) > 0 {
+		return ErrDoubleFree
+	}
+
+	a.free = a.free.Union(Multispan{s}).NormalizeWithin(1)
+
+	return nil
+}
+
+// Available is the normalized multispan of free integers
+func (a *Allocator) Available() Multispan {
+	return append(Multispan{}, a.free...)
+}
+
+// Allocated is the normalized multispan of integers in use
+func (a *Allocator) Allocated() Multispan {
+	return a.pool.Subtract(a.free)
+}
+
+func (a *Allocator) Stats() AllocStats {
+
+	st := AllocStats{
+		Total:      a.pool.Size(),
+		Free:       a.free.Size(),
+		FreeRanges: len(a.free),
+	}
+	st.Used = st.Total - st.Free
+
+	for _, s := range a.free {
+		st.LargestFree = max(st.LargestFree, s.Len())
+	}
+
+	return st
+}
+
+// MarshalText writes the pool and the allocated integers in the notation
+// read by Parse, separated by a semicolon: "1000-1999,3000-3999;1000-1010"
+func (a *Allocator) MarshalText() ([]byte, error) {
+	return []byte(a.pool.String() + ";" + a.Allocated().String()), nil
+}
+
+// UnmarshalText restores what MarshalText wrote, keeping the allocator's
+// policy
+func (a *Allocator) UnmarshalText(text []byte) error {
+
+	parts := strings.SplitN(string(text), ";", 2)
+	if len(parts) != 2 {
+		return ErrSyntax
+	}
+
+	pool, err := Parse(parts[0])
+	if err != nil {
+		return err
+	}
+
+	used, err := Parse(parts[1])
+	if err != nil {
+		return err
+	}
+
+	restored, err := NewAllocator(pool, a.policy)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range used.NormalizeWithin(1) {
+		if err := restored.AllocSpecific(s); err != nil {
+			return err
+		}
+	}
+
+	*a = *restored
+
+	return nil
+}
+
+func (a *Allocator) take(s Span) {
+	a.free = a.free.Subtract(Multispan{s})
+	a.cursor = s.End + 1
+}
+
+func (a *Allocator) inPool(s Span) error {
+	if pool := a.pool.Intersect(Multispan{s}); len(pool) != 1 || pool[0] != s {
+		return ErrOutOfPool
+	}
+
+	return nil
+}
+
+// firstFit finds the lowest n free integers starting at or after from
+func (a *Allocator) firstFit(n, from int) (Span, error) {
+
+	for i := a.free.search(from); i < len(a.free); i++ {
+
+		s := a.free[i]
+		s.Start = max(s.Start, from)
+
+		if s.Len() >= n {
+			return Span{Start: s.Start, End: s.Start + n - 1}, nil
+		}
+	}
+
+	return Zero, ErrExhausted
+}
+
+func (a *Allocator) bestFit(n int) (Span, error) {
+
+	best := -1
+
+	for i, s := range a.free {
+		if s.Len() >= n && (best < 0 || s.Len() < a.free[best].Len()) {
+			best = i
+		}
+	}
+
+	if best < 0 {
+		return Zero, ErrExhausted
+	}
+
+	s := a.free[best]
+
+	return Span{Start: s.Start, End: s.Start + n - 1}, nil
+}
+
+// nextFit looks from the cursor to the end of the pool, then wraps round
+func (a *Allocator) nextFit(n int) (Span, error) {
+
+	if s, err := a.firstFit(n, a.cursor); err == nil {
+		return s, nil
+	}
+
+	return a.firstFit(n, NegInf)
+}
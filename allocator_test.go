@@ -0,0 +1,109 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestAllocator(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a first fit allocator over two ranges", t, func() {
+		pool, _ := Parse("1000-1009,2000-2019")
+		a, err := NewAllocator(pool, FirstFit)
+		So(err, ShouldBeNil)
+
+		Convey("Alloc() and AllocN() should take the lowest free integers", func() {
+			n, err := a.Alloc()
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 1000)
+
+			s, err := a.AllocN(12)
+			So(err, ShouldBeNil)
+			So(s, ShouldResemble, Span{2000, 2011})
+
+			s, _ = a.AllocN(9)
+			So(s, ShouldResemble, Span{1001, 1009})
+
+			_, err = a.AllocN(9)
+			So(err, ShouldEqual, ErrExhausted)
+		})
+
+		Convey("AllocSpecific() should only take free integers in the pool", func() {
+			So(a.AllocSpecific(Span{1005, 1006}), ShouldBeNil)
+			So(a.AllocSpecific(Span{1006, 1007}), ShouldEqual, ErrInUse)
+			So(a.AllocSpecific(Span{1008, 1010}), ShouldEqual, ErrOutOfPool)
+			So(a.Allocated(), ShouldResemble, Multispan{{1005, 1006}})
+		})
+
+		Convey("Free() should catch double frees", func() {
+			s, _ := a.AllocN(4)
+
+			So(a.Free(Span{1001, 1002}), ShouldBeNil)
+			So(a.Free(Span{1002, 1003}), ShouldEqual, ErrDoubleFree)
+			So(a.Free(Span{1008, 1008}), ShouldEqual, ErrDoubleFree)
+			So(a.Free(Span{500, 500}), ShouldEqual, ErrOutOfPool)
+			So(a.Free(s), ShouldEqual, ErrDoubleFree)
+			So(a.Available(), ShouldResemble, Multispan{{1001, 1002}, {1004, 1009}, {2000, 2019}})
+		})
+
+		Convey("Stats() should report utilization", func() {
+			a.AllocN(5)
+			a.AllocSpecific(Span{2010, 2010})
+
+			st := a.Stats()
+			So(st, ShouldResemble, AllocStats{Total: 30, Used: 6, Free: 24, LargestFree: 10, FreeRanges: 3})
+			So(st.Utilization(), ShouldEqual, 0.2)
+		})
+
+		Convey("The allocator should survive a text round trip", func() {
+			a.AllocN(3)
+			a.AllocSpecific(Span{2005, 2006})
+
+			text, err := a.MarshalText()
+			So(err, ShouldBeNil)
+			So(string(text), ShouldEqual, "1000-1009,2000-2019;1000-1002,2005-2006")
+
+			b := &Allocator{}
+			So(b.UnmarshalText(text), ShouldBeNil)
+			So(b.Available(), ShouldResemble, a.Available())
+			So(b.UnmarshalText([]byte("1-10")), ShouldEqual, ErrSyntax)
+		})
+	})
+
+	Convey("Given allocators with other policies", t, func() {
+		pool := Multispan{{1, 10}, {20, 22}, {30, 34}}
+
+		Convey("Best fit should take the smallest range that fits", func() {
+			a, _ := NewAllocator(pool, BestFit)
+
+			s, _ := a.AllocN(3)
+			So(s, ShouldResemble, Span{20, 22})
+			s, _ = a.AllocN(3)
+			So(s, ShouldResemble, Span{30, 32})
+		})
+
+		Convey("Next fit should carry on from the last allocation and wrap", func() {
+			a, _ := NewAllocator(pool, NextFit)
+
+			s, _ := a.AllocN(2)
+			So(s, ShouldResemble, Span{1, 2})
+			a.Free(s)
+
+			s, _ = a.AllocN(2)
+			So(s, ShouldResemble, Span{3, 4})
+
+			s, _ = a.AllocN(6)
+			So(s, ShouldResemble, Span{5, 10})
+
+			s, _ = a.AllocN(4)
+			So(s, ShouldResemble, Span{30, 33})
+
+			s, _ = a.AllocN(2)
+			So(s, ShouldResemble, Span{1, 2})
+		})
+
+		Convey("An unbounded pool should be rejected", func() {
+			_, err := NewAllocator(Multispan{From(1)}, FirstFit)
+			So(err, ShouldEqual, ErrUnbounded)
+		})
+	})
+}
@@ -0,0 +1,313 @@
+package span
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var ErrCorrupt = errors.New("Checkpoint log is corrupt")
+var ErrClosed = errors.New("Checkpoint is closed")
+var ErrBelowBase = errors.New("Span starts below the checkpoint base")
+
+// CompactError is returned by Add when the addition was recorded durably
+// but the compaction it triggered failed. The log is left as it was, so
+// nothing is lost and the next addition tries again.
+type CompactError struct {
+	Err error
+}
+
+func (e *CompactError) Error() string {
+	return "Checkpoint compaction failed: " + e.Err.Error()
+}
+
+// Compact after this many additions unless told otherwise
+const defaultCompactEvery = 1024
+
+// Checkpoint is a durable record of processed ranges, such as consumed
+// offsets, kept in two local files: a snapshot at path holding the
+// normalized multispan, and a write-ahead log at path+".wal" with one span
+// per line. Every Add is appended to the log and synced before it counts.
+// Compaction folds the log into a new snapshot, written beside the old one
+// and renamed over it, then empties the log.
+type Checkpoint struct {
+	// CompactEvery is how many additions trigger a compaction; zero or
+	// less turns automatic compaction off
+	CompactEvery int
+
+	mu      sync.Mutex
+	path    string
+	wal     *os.File
+	tracker *SeqTracker
+	pending int
+	failed  error // set when a failed append couldn't be rolled back
+}
+
+// OpenCheckpoint opens or creates the checkpoint at path, recovering the
+// snapshot and replaying the log. A torn final log line, left by a crash
+// mid-append, is discarded.
+func OpenCheckpoint(path string, base int) (*Checkpoint, error) {
+
+	cp := &Checkpoint{
+		CompactEvery: defaultCompactEvery,
+		path:         path,
+		tracker:      NewSeqTracker(base),
+	}
+
+	snap, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	ms, err := Parse(string(bytes.TrimSpace(snap)))
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+
+	logged, good, err := cp.replay(path + ".wal")
+	if err != nil {
+		return nil, err
+	}
+
+	cp.tracker.markAll(append(ms, logged...))
+
+	cp.wal, err = os.OpenFile(path+".wal", os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	// drop any torn tail so new lines start clean
+	if err := cp.wal.Truncate(good); err != nil {
+		cp.wal.Close()
+		return nil, err
+	}
+
+	if _, err := cp.wal.Seek(good, 0); err != nil {
+		cp.wal.Close()
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// replay reads every complete log line, returning the spans logged and
+// the length of the complete part of the log
+func (cp *Checkpoint) replay(name string) (Multispan, int64, error) {
+
+	log, err := ioutil.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	logged := NewMultiSpan(0)
+	good := 0
+
+	for {
+		i := bytes.IndexByte(log[good:], '\n')
+		if i < 0 {
+			break
+		}
+
+		ms, err := Parse(string(log[good : good+i]))
+		if err != nil {
+			return nil, 0, ErrCorrupt
+		}
+		logged = append(logged, ms...)
+		cp.pending++
+
+		good += i + 1
+	}
+
+	return logged, int64(good), nil
+}
+
+// Add durably records s as processed. Spans starting below the base are
+// rejected with ErrBelowBase. If the append fails it is cut back out of
+// the log; should that fail too, every later Add returns the error.
+func (cp *Checkpoint) Add(s Span) error {
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if cp.wal == nil {
+		return ErrClosed
+	}
+
+	if cp.failed != nil {
+		return cp.failed
+	}
+
+	s = s.Normalize()
+
+	if s.Start < cp.tracker.base {
+		return ErrBelowBase
+	}
+
+	if err := cp.append([]byte(s.String() + "\n")); err != nil {
+		return err
+	}
+
+	cp.tracker.MarkRange(s)
+	cp.pending++
+
+	if cp.CompactEvery > 0 && cp.pending >= cp.CompactEvery {
+		if err := cp.compact(); err != nil {
+			return &CompactError{Err: err}
+		}
+	}
+
+	return nil
+}
+
+// append writes and syncs one log line, truncating the log back to where
+// it was if either fails so a torn line can't run into the next one
+func (cp *Checkpoint) append(line []byte) error {
+
+	end, err := cp.wal.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	_, err = cp.wal.Write(line)
+	if err == nil {
+		err = cp.wal.Sync()
+	}
+	if err == nil {
+		return nil
+	}
+
+	if terr := cp.wal.Truncate(end); terr != nil {
+		cp.failed = terr
+	} else if _, serr := cp.wal.Seek(end, io.SeekStart); serr != nil {
+		cp.failed = serr
+	}
+
+	return err
+}
+
+func (cp *Checkpoint) Contains(n int) bool {
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	return cp.tracker.Has(n)
+}
+
+// Watermark is the highest n such that everything from the base up to n
+// has been processed
+func (cp *Checkpoint) Watermark() int {
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	return cp.tracker.Watermark()
+}
+
+// Processed is the normalized multispan of everything processed
+func (cp *Checkpoint) Processed() Multispan {
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	return cp.tracker.Received()
+}
+
+// Compact writes a fresh snapshot and empties the log
+func (cp *Checkpoint) Compact() error {
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if cp.wal == nil {
+		return ErrClosed
+	}
+
+	return cp.compact()
+}
+
+func (cp *Checkpoint) compact() error {
+
+	tmp := cp.path + ".tmp"
+
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write([]byte(cp.tracker.Received().String() + "\n"))
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, cp.path); err != nil {
+		return err
+	}
+
+	if err := syncDir(filepath.Dir(cp.path)); err != nil {
+		return err
+	}
+
+	// a crash before this point only leaves log lines the snapshot
+	// already holds, which replay harmlessly
+	if err := cp.wal.Truncate(0); err != nil {
+		return err
+	}
+
+	if _, err := cp.wal.Seek(0, 0); err != nil {
+		return err
+	}
+
+	cp.pending = 0
+
+	return cp.wal.Sync()
+}
+
+// Close compacts and releases the log
+func (cp *Checkpoint) Close() error {
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if cp.wal == nil {
+		return ErrClosed
+	}
+
+	err := cp.compact()
+
+	if cerr := cp.wal.Close(); err == nil {
+		err = cerr
+	}
+	cp.wal = nil
+
+	return err
+}
+
+// syncDir flushes a directory so a rename within it is durable
+func syncDir(dir string) error {
+
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	err = d.Sync()
+	if cerr := d.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
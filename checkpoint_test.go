@@ -0,0 +1,168 @@
+package span
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestCheckpoint(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a checkpoint in an empty directory", t, func() {
+		dir, err := ioutil.TempDir("", "checkpoint")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "offsets")
+		cp, err := OpenCheckpoint(path, 0)
+		So(err, ShouldBeNil)
+
+		So(cp.Add(Span{0, 9}), ShouldBeNil)
+		So(cp.Add(Span{20, 29}), ShouldBeNil)
+		So(cp.Add(Span{10, 12}), ShouldBeNil)
+
+		Convey("It should answer from memory", func() {
+			So(cp.Contains(11), ShouldBeTrue)
+			So(cp.Contains(15), ShouldBeFalse)
+			So(cp.Watermark(), ShouldEqual, 12)
+			So(cp.Processed(), ShouldResemble, Multispan{{0, 12}, {20, 29}})
+		})
+
+		Convey("A crash should be recovered from the log", func() {
+			// simulate a crash: abandon cp and tear the last line
+			f, _ := os.OpenFile(path+".wal", os.O_APPEND|os.O_WRONLY, 0644)
+			f.Write([]byte("13-1"))
+			f.Close()
+
+			re, err := OpenCheckpoint(path, 0)
+			So(err, ShouldBeNil)
+			So(re.Processed(), ShouldResemble, Multispan{{0, 12}, {20, 29}})
+
+			So(re.Add(Span{13, 19}), ShouldBeNil)
+			So(re.Watermark(), ShouldEqual, 29)
+
+			log, _ := ioutil.ReadFile(path + ".wal")
+			So(string(log), ShouldEqual, "0-9\n20-29\n10-12\n13-19\n")
+		})
+
+		Convey("Compaction should fold the log into the snapshot", func() {
+			So(cp.Compact(), ShouldBeNil)
+
+			snap, _ := ioutil.ReadFile(path)
+			So(string(snap), ShouldEqual, "0-12,20-29\n")
+
+			log, _ := ioutil.ReadFile(path + ".wal")
+			So(log, ShouldBeEmpty)
+
+			So(cp.Add(Span{40, 40}), ShouldBeNil)
+			So(cp.Close(), ShouldBeNil)
+			So(cp.Add(Span{41, 41}), ShouldEqual, ErrClosed)
+
+			re, err := OpenCheckpoint(path, 0)
+			So(err, ShouldBeNil)
+			So(re.Processed(), ShouldResemble, Multispan{{0, 12}, {20, 29}, {40, 40}})
+		})
+
+		Convey("Additions should trigger compaction automatically", func() {
+			cp.CompactEvery = 4
+			So(cp.Add(Span{50, 50}), ShouldBeNil)
+
+			log, _ := ioutil.ReadFile(path + ".wal")
+			So(log, ShouldBeEmpty)
+		})
+
+		Convey("Negative offsets should survive a reopen", func() {
+			neg := filepath.Join(dir, "negative")
+			cp, err := OpenCheckpoint(neg, -10)
+			So(err, ShouldBeNil)
+
+			So(cp.Add(Span{-10, -5}), ShouldBeNil)
+			So(cp.Add(Span{-3, 2}), ShouldBeNil)
+
+			re, err := OpenCheckpoint(neg, -10)
+			So(err, ShouldBeNil)
+			So(re.Processed(), ShouldResemble, Multispan{{-10, -5}, {-3, 2}})
+			So(re.Watermark(), ShouldEqual, -5)
+
+			So(re.Close(), ShouldBeNil)
+			re, err = OpenCheckpoint(neg, -10)
+			So(err, ShouldBeNil)
+			So(re.Processed(), ShouldResemble, Multispan{{-10, -5}, {-3, 2}})
+		})
+
+		Convey("A fragmented snapshot and log should be recovered together", func() {
+			frag := filepath.Join(dir, "fragmented")
+
+			snap := NewMultiSpan(0)
+			for i := 0; i < 20000; i++ {
+				snap = append(snap, Span{4 * i, 4*i + 1})
+			}
+			ioutil.WriteFile(frag, []byte(snap.String()+"\n"), 0644)
+			ioutil.WriteFile(frag+".wal", []byte("2-3\n6\n"), 0644)
+
+			re, err := OpenCheckpoint(frag, 0)
+			So(err, ShouldBeNil)
+			So(re.Watermark(), ShouldEqual, 6)
+			So(len(re.Processed()), ShouldEqual, 19999)
+			So(re.Contains(79997), ShouldBeTrue)
+			So(re.Contains(79998), ShouldBeFalse)
+		})
+
+		Convey("Spans below the base should be rejected before logging", func() {
+			high := filepath.Join(dir, "high")
+			cp, err := OpenCheckpoint(high, 100)
+			So(err, ShouldBeNil)
+
+			So(cp.Add(Span{5, 10}), ShouldEqual, ErrBelowBase)
+			So(cp.Add(Span{95, 105}), ShouldEqual, ErrBelowBase)
+			So(cp.Add(Span{100, 105}), ShouldBeNil)
+
+			log, _ := ioutil.ReadFile(high + ".wal")
+			So(string(log), ShouldEqual, "100-105\n")
+		})
+
+		Convey("A failed append that can't be undone should stop later adds", func() {
+			wal := cp.wal
+			defer wal.Close()
+
+			ro, err := os.Open(path + ".wal")
+			So(err, ShouldBeNil)
+			defer ro.Close()
+			ro.Seek(0, io.SeekEnd)
+			cp.wal = ro
+
+			err = cp.Add(Span{40, 40})
+			So(err, ShouldNotBeNil)
+			So(cp.Add(Span{41, 41}), ShouldEqual, cp.failed)
+
+			re, err := OpenCheckpoint(path, 0)
+			So(err, ShouldBeNil)
+			So(re.Processed(), ShouldResemble, Multispan{{0, 12}, {20, 29}})
+		})
+
+		Convey("A failed compaction should not fail the add", func() {
+			So(os.Mkdir(path+".tmp", 0755), ShouldBeNil)
+			cp.CompactEvery = 4
+
+			err := cp.Add(Span{50, 50})
+			_, compact := err.(*CompactError)
+			So(compact, ShouldBeTrue)
+			So(cp.Contains(50), ShouldBeTrue)
+
+			re, err := OpenCheckpoint(path, 0)
+			So(err, ShouldBeNil)
+			So(re.Contains(50), ShouldBeTrue)
+		})
+
+		Convey("A corrupt log should be reported", func() {
+			ioutil.WriteFile(path+".wal", []byte("1-2\nxyz\n3\n"), 0644)
+
+			_, err := OpenCheckpoint(path, 0)
+			So(err, ShouldEqual, ErrCorrupt)
+		})
+	})
+}
@@ -0,0 +1,203 @@
+package span
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrCircularSize = errors.New("Circular domain size must be positive")
+var ErrOutOfDomain = errors.New("Value is outside the circular domain")
+
+// Circular is a domain of integers 0 to Size-1 that wraps around, such as
+// hours of the day or compass degrees
+type Circular struct {
+	size int
+}
+
+func NewCircular(size int) (Circular, error) {
+	if size < 1 {
+		return Circular{}, ErrCircularSize
+	}
+
+	return Circular{size: size}, nil
+}
+
+func (c Circular) Size() int {
+	return c.size
+}
+
+// Full is the arc covering the whole domain
+func (c Circular) Full() Arc {
+	return Arc{start: 0, end: c.size - 1, size: c.size}
+}
+
+// Arc returns the arc running forward from start to end, wrapping past
+// zero when end < start: in a 24 hour domain, Arc(22, 2) is 22,23,0,1,2.
+func (c Circular) Arc(start, end int) (Arc, error) {
+	if start < 0 || start >= c.size || end < 0 || end >= c.size {
+		return Arc{}, ErrOutOfDomain
+	}
+
+	return Arc{start: start, end: end, size: c.size}, nil
+}
+
+// Arc is a closed span in a circular domain, made by Circular.Arc. Arcs
+// from different domains must not be mixed. The zero Arc is empty.
+type Arc struct {
+	start int
+	end   int
+	size  int
+}
+
+func (a Arc) Start() int {
+	return a.start
+}
+
+func (a Arc) End() int {
+	return a.end
+}
+
+func (a Arc) Wraps() bool {
+	return a.end < a.start
+}
+
+// Len is the number of integers in the arc
+func (a Arc) Len() int {
+	if a.size == 0 {
+		return 0
+	}
+
+	return (a.end-a.start+a.size)%a.size + 1
+}
+
+// Contains reports whether n, taken modulo the domain size, is in the arc
+func (a Arc) Contains(n int) bool {
+	if a.size == 0 {
+		return false
+	}
+
+	n = (n%a.size + a.size) % a.size
+
+	if a.Wraps() {
+		return n >= a.start || n <= a.end
+	}
+
+	return a.start <= n && n <= a.end
+}
+
+func (a Arc) Overlaps(b Arc) bool {
+	if a.size == 0 || b.size == 0 {
+		return false
+	}
+
+	return a.Contains(b.start) || b.Contains(a.start)
+}
+
+// Spans converts the arc to plain spans: one, or two if it wraps
+func (a Arc) Spans() Multispan {
+	switch {
+	case a.size == 0:
+		return NewMultiSpan(0)
+	case a.Wraps():
+		return Multispan{{Start: 0, End: a.end}, {Start: a.start, End: a.size - 1}}
+	}
+
+	return Multispan{{Start: a.start, End: a.end}}
+}
+
+// String formats the arc as Circular.Parse reads it
+func (a Arc) String() string {
+	if a.size == 0 {
+		return ""
+	}
+
+	return Span{Start: a.start, End: a.end}.String()
+}
+
+// Union is every integer in any of the arcs, as few arcs as possible in
+// order of start, with an arc across zero last
+func (c Circular) Union(arcs ...Arc) []Arc {
+	return c.arcs(c.spans(arcs))
+}
+
+// Intersect is every integer in both sets of arcs. Two arcs can meet in
+// two places, so the result may have more arcs than either input.
+func (c Circular) Intersect(a, b []Arc) []Arc {
+	return c.arcs(c.spans(a).Intersect(c.spans(b)))
+}
+
+// Complement is every integer in the domain not in the arcs
+func (c Circular) Complement(arcs []Arc) []Arc {
+	return c.arcs(Multispan{{Start: 0, End: c.size - 1}}.Subtract(c.spans(arcs)))
+}
+
+// Parse reads arcs in the notation of Parse, except that a range with a
+// smaller end wraps instead of being flipped, so "22-2" runs through
+// midnight. Open ends run to the edge of the domain and "*" is the whole
+// domain. Steps are not supported.
+func (c Circular) Parse(s string) ([]Arc, error) {
+
+	if len(s) == 0 {
+		return []Arc{}, nil
+	}
+
+	arcs := make([]Arc, 0, strings.Count(s, ",")+1)
+
+	for _, elem := range strings.Split(s, ",") {
+
+		rng, err := parseRange(strings.TrimSpace(elem))
+		if err != nil {
+			return nil, err
+		}
+
+		if rng.Start == NegInf {
+			rng.Start = 0
+		}
+		if rng.End == PosInf {
+			rng.End = c.size - 1
+		}
+
+		a, err := c.Arc(rng.Start, rng.End)
+		if err != nil {
+			return nil, err
+		}
+
+		arcs = append(arcs, a)
+	}
+
+	return arcs, nil
+}
+
+// spans is the normalized multispan of integers in the arcs
+func (c Circular) spans(arcs []Arc) Multispan {
+
+	ms := NewMultiSpan(len(arcs))
+
+	for _, a := range arcs {
+		ms = append(ms, a.Spans()...)
+	}
+
+	return ms.NormalizeWithin(1)
+}
+
+// arcs turns a normalized multispan within the domain back into arcs,
+// joining the pieces at either end into one arc across zero
+func (c Circular) arcs(ms Multispan) []Arc {
+
+	ms = ms.NormalizeWithin(1)
+	arcs := make([]Arc, 0, len(ms))
+
+	n := len(ms)
+	if n > 1 && ms[0].Start == 0 && ms[n-1].End == c.size-1 {
+		for _, s := range ms[1 : n-1] {
+			arcs = append(arcs, Arc{start: s.Start, end: s.End, size: c.size})
+		}
+		return append(arcs, Arc{start: ms[n-1].Start, end: ms[0].End, size: c.size})
+	}
+
+	for _, s := range ms {
+		arcs = append(arcs, Arc{start: s.Start, end: s.End, size: c.size})
+	}
+
+	return arcs
+}
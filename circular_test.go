@@ -0,0 +1,101 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestArc(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given business hours across midnight", t, func() {
+		day, err := NewCircular(24)
+		So(err, ShouldBeNil)
+
+		night, err := day.Arc(22, 2)
+		So(err, ShouldBeNil)
+
+		Convey("The arc should wrap instead of flipping", func() {
+			So(night.Wraps(), ShouldBeTrue)
+			So(night.Len(), ShouldEqual, 5)
+			So(night.Contains(23), ShouldBeTrue)
+			So(night.Contains(0), ShouldBeTrue)
+			So(night.Contains(26), ShouldBeTrue)
+			So(night.Contains(12), ShouldBeFalse)
+			So(night.Spans(), ShouldResemble, Multispan{{0, 2}, {22, 23}})
+		})
+
+		Convey("Overlaps() should see across midnight", func() {
+			early, _ := day.Arc(1, 6)
+			noon, _ := day.Arc(11, 13)
+
+			So(night.Overlaps(early), ShouldBeTrue)
+			So(early.Overlaps(night), ShouldBeTrue)
+			So(night.Overlaps(noon), ShouldBeFalse)
+		})
+
+		Convey("The accessors should report the ends as given", func() {
+			So(night.Start(), ShouldEqual, 22)
+			So(night.End(), ShouldEqual, 2)
+		})
+
+		Convey("Values outside the domain should be rejected", func() {
+			a, err := day.Arc(22, 24)
+			So(err, ShouldEqual, ErrOutOfDomain)
+
+			// the zero arc from the error path is empty
+			So(a.Len(), ShouldEqual, 0)
+			So(a.Contains(0), ShouldBeFalse)
+			So(a.Overlaps(night), ShouldBeFalse)
+			So(a.Spans(), ShouldBeEmpty)
+			So(a.String(), ShouldEqual, "")
+
+			_, err = NewCircular(0)
+			So(err, ShouldEqual, ErrCircularSize)
+		})
+	})
+}
+
+func TestCircularSets(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given arcs in a 360 degree domain", t, func() {
+		compass, _ := NewCircular(360)
+		north, _ := compass.Arc(315, 45)
+		east, _ := compass.Arc(45, 135)
+		wide, _ := compass.Arc(300, 60)
+
+		Convey("Union() should join arcs across zero", func() {
+			So(compass.Union(north, east), ShouldResemble, []Arc{{315, 135, 360}})
+			So(compass.Union(east, wide), ShouldResemble, []Arc{{300, 135, 360}})
+		})
+
+		Convey("Intersect() may split an arc in two", func() {
+			back, _ := compass.Arc(30, 330)
+			So(compass.Intersect([]Arc{wide}, []Arc{back}), ShouldResemble, []Arc{{30, 60, 360}, {300, 330, 360}})
+			So(compass.Intersect([]Arc{north}, []Arc{east}), ShouldResemble, []Arc{{45, 45, 360}})
+		})
+
+		Convey("Complement() should wrap too", func() {
+			So(compass.Complement([]Arc{east}), ShouldResemble, []Arc{{136, 44, 360}})
+			So(compass.Complement([]Arc{north}), ShouldResemble, []Arc{{46, 314, 360}})
+			So(compass.Complement([]Arc{compass.Full()}), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestCircularParse(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a 24 hour domain", t, func() {
+		day, _ := NewCircular(24)
+
+		Convey("Parse() should read wrapping ranges", func() {
+			arcs, err := day.Parse("22-2,9-17,20-,*")
+			So(err, ShouldBeNil)
+			So(arcs, ShouldResemble, []Arc{{22, 2, 24}, {9, 17, 24}, {20, 23, 24}, {0, 23, 24}})
+			So(arcs[0].String(), ShouldEqual, "22-2")
+
+			_, err = day.Parse("22-25")
+			So(err, ShouldEqual, ErrOutOfDomain)
+		})
+	})
+}
@@ -0,0 +1,132 @@
+package span
+
+import (
+	"sort"
+)
+
+// Direction says which side of a query a span lies on
+type Direction int
+
+const (
+	Either     Direction = iota
+	Upstream             // before the query
+	Downstream           // after the query
+)
+
+// SpanIndex holds spans sorted both ways for closest span queries
+type SpanIndex struct {
+	spans   []LabeledSpan
+	byStart []int
+	byEnd   []int
+}
+
+// Hit is a span found by Closest: its index in the indexed input, how far
+// it is from the query as measured by Gap, and which side it is on
+type Hit struct {
+	Index     int
+	Distance  int
+	Direction Direction
+}
+
+func NewSpanIndex(spans []LabeledSpan) *SpanIndex {
+
+	idx := &SpanIndex{
+		spans:   make([]LabeledSpan, len(spans)),
+		byStart: make([]int, len(spans)),
+		byEnd:   make([]int, len(spans)),
+	}
+
+	for i, s := range spans {
+		idx.spans[i] = s
+		idx.spans[i].Span = s.Normalize()
+		idx.byStart[i] = i
+		idx.byEnd[i] = i
+	}
+
+	sort.SliceStable(idx.byStart, func(a, b int) bool {
+		return idx.spans[idx.byStart[a]].Start < idx.spans[idx.byStart[b]].Start
+	})
+
+	sort.SliceStable(idx.byEnd, func(a, b int) bool {
+		return idx.spans[idx.byEnd[a]].End < idx.spans[idx.byEnd[b]].End
+	})
+
+	return idx
+}
+
+// SpanIndex indexes the spans of a multispan, with hits referring to
+// positions in it
+func (ms Multispan) SpanIndex() *SpanIndex {
+
+	spans := make([]LabeledSpan, len(ms))
+
+	for i, s := range ms {
+		spans[i] = LabeledSpan{Span: s}
+	}
+
+	return NewSpanIndex(spans)
+}
+
+// Get returns the i-th indexed span
+func (idx *SpanIndex) Get(i int) LabeledSpan {
+	return idx.spans[i]
+}
+
+// Closest finds up to k spans that do not overlap the query, nearest
+// first, looking only in the given direction. Equally distant spans are
+// ordered upstream first, then by position.
+func Closest(idx *SpanIndex, query Span, k int, dir Direction) []Hit {
+
+	if k <= 0 {
+		return nil
+	}
+
+	query = query.Normalize()
+	hits := make([]Hit, 0, k)
+
+	// upstream spans end before the query starts; walk back from the
+	// latest end
+	up := sort.Search(len(idx.byEnd), func(i int) bool {
+		return idx.spans[idx.byEnd[i]].End >= query.Start
+	}) - 1
+
+	// downstream spans start after the query ends
+	down := sort.Search(len(idx.byStart), func(i int) bool {
+		return idx.spans[idx.byStart[i]].Start > query.End
+	})
+
+	if dir == Downstream {
+		up = -1
+	}
+	if dir == Upstream {
+		down = len(idx.byStart)
+	}
+
+	for len(hits) < k && (up >= 0 || down < len(idx.byStart)) {
+
+		var uh, dh Hit
+		if up >= 0 {
+			uh = idx.hit(idx.byEnd[up], query, Upstream)
+		}
+		if down < len(idx.byStart) {
+			dh = idx.hit(idx.byStart[down], query, Downstream)
+		}
+
+		if up >= 0 && (down == len(idx.byStart) || uh.Distance <= dh.Distance) {
+			hits = append(hits, uh)
+			up--
+		} else {
+			hits = append(hits, dh)
+			down++
+		}
+	}
+
+	return hits
+}
+
+func (idx *SpanIndex) hit(i int, query Span, dir Direction) Hit {
+
+	gap, _ := idx.spans[i].Gap(query)
+
+	return Hit{Index: i, Distance: distance(gap.Start, gap.End), Direction: dir}
+}
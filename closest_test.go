@@ -0,0 +1,70 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestClosest(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given an index over reference spans", t, func() {
+		ref := []LabeledSpan{
+			{Span: Span{50, 60}, Label: "e"},
+			{Span: Span{1, 5}, Label: "a"},
+			{Span: Span{8, 9}, Label: "b"},
+			{Span: Span{18, 25}, Label: "c"},
+			{Span: Span{27, 29}, Label: "d"},
+			{Span: Span{12, 14}, Label: "x"},
+		}
+		idx := NewSpanIndex(ref)
+		query := Span{12, 20}
+
+		Convey("Closest() should skip overlapping spans and find the nearest", func() {
+			So(Closest(idx, query, 1, Either), ShouldResemble, []Hit{{Index: 2, Distance: 3, Direction: Upstream}})
+		})
+
+		Convey("Closest() should return k nearest in order of distance", func() {
+			So(Closest(idx, query, 4, Either), ShouldResemble, []Hit{
+				{Index: 2, Distance: 3, Direction: Upstream},
+				{Index: 1, Distance: 7, Direction: Upstream},
+				{Index: 4, Distance: 7, Direction: Downstream},
+				{Index: 0, Distance: 30, Direction: Downstream},
+			})
+		})
+
+		Convey("Closest() should return nothing when k isn't positive", func() {
+			So(Closest(idx, query, 0, Either), ShouldBeNil)
+			So(Closest(idx, query, -1, Either), ShouldBeNil)
+		})
+
+		Convey("Closest() should respect the direction", func() {
+			So(Closest(idx, query, 5, Downstream), ShouldResemble, []Hit{
+				{Index: 4, Distance: 7, Direction: Downstream},
+				{Index: 0, Distance: 30, Direction: Downstream},
+			})
+			So(Closest(idx, Span{0, 3}, 2, Upstream), ShouldBeEmpty)
+			So(idx.Get(2).Label, ShouldEqual, "b")
+		})
+	})
+
+	Convey("Given an index built from a multispan", t, func() {
+		idx := Multispan{{1, 2}, {10, 12}, {14, 14}}.SpanIndex()
+
+		Convey("Hits should refer to positions in the multispan", func() {
+			So(Closest(idx, Span{13, 13}, 2, Either), ShouldResemble, []Hit{
+				{Index: 1, Distance: 1, Direction: Upstream},
+				{Index: 2, Distance: 1, Direction: Downstream},
+			})
+		})
+	})
+
+	Convey("Given spans at the extremes", t, func() {
+		idx := Multispan{UpTo(-5), {PosInf - 1, PosInf - 1}}.SpanIndex()
+
+		Convey("Distances should saturate instead of going negative", func() {
+			So(Closest(idx, Span{PosInf - 3, PosInf - 3}, 2, Either), ShouldResemble, []Hit{
+				{Index: 1, Distance: 2, Direction: Downstream},
+				{Index: 0, Distance: PosInf, Direction: Upstream},
+			})
+		})
+	})
+}
@@ -0,0 +1,60 @@
+package span
+
+import (
+	"sort"
+)
+
+// Group is one connected cluster of spans: the indexes of its members in
+// the input and the hull covering them all
+type Group struct {
+	Hull    Span
+	Members []int
+}
+
+// Cluster groups spans that overlap, directly or through other spans, in
+// the way Normalize merges them, but keeps track of which inputs formed
+// each group. Groups come out in order of position, with member indexes
+// ascending.
+func Cluster(spans []Span) []Group {
+	return ClusterWithin(spans, 0)
+}
+
+// ClusterWithin is Cluster with a tolerance: spans whose Gap is at most k
+// join the same group, as in NormalizeWithin.
+func ClusterWithin(spans []Span, k int) []Group {
+
+	norm := make([]Span, len(spans))
+	order := make([]int, len(spans))
+	for i := range order {
+		norm[i] = spans[i].Normalize()
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return norm[order[a]].Start < norm[order[b]].Start
+	})
+
+	groups := make([]Group, 0)
+
+	for _, i := range order {
+
+		s := norm[i]
+
+		if n := len(groups); n > 0 {
+			g := &groups[n-1]
+			if gap, err := g.Hull.Gap(s); err != nil || distance(gap.Start, gap.End) <= k {
+				g.Hull.End = max(g.Hull.End, s.End)
+				g.Members = append(g.Members, i)
+				continue
+			}
+		}
+
+		groups = append(groups, Group{Hull: s, Members: []int{i}})
+	}
+
+	for _, g := range groups {
+		sort.Ints(g.Members)
+	}
+
+	return groups
+}
@@ -0,0 +1,56 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestCluster(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given unsorted records with overlapping spans", t, func() {
+		spans := []Span{{20, 25}, {1, 4}, {9, 12}, {3, 6}, {24, 30}, {8, 8}, {5, 5}}
+
+		Convey("Cluster() should group overlapping records and keep their indexes", func() {
+			So(Cluster(spans), ShouldResemble, []Group{
+				{Hull: Span{1, 6}, Members: []int{1, 3, 6}},
+				{Hull: Span{8, 8}, Members: []int{5}},
+				{Hull: Span{9, 12}, Members: []int{2}},
+				{Hull: Span{20, 30}, Members: []int{0, 4}},
+			})
+		})
+
+		Convey("The hulls should match Normalize()", func() {
+			groups := Cluster(spans)
+			ms := Multispan(append([]Span{}, spans...)).Normalize()
+
+			So(len(groups), ShouldEqual, ms.Len())
+			for i, g := range groups {
+				So(g.Hull, ShouldResemble, ms.Get(i))
+			}
+		})
+
+		Convey("ClusterWithin() should also join nearby records", func() {
+			So(ClusterWithin(spans, 2), ShouldResemble, []Group{
+				{Hull: Span{1, 12}, Members: []int{1, 2, 3, 5, 6}},
+				{Hull: Span{20, 30}, Members: []int{0, 4}},
+			})
+			So(ClusterWithin(nil, 2), ShouldBeEmpty)
+		})
+
+		Convey("Gaps wider than an int should keep groups apart", func() {
+			So(ClusterWithin([]Span{UpTo(-5), {PosInf - 1, PosInf - 1}}, 3), ShouldResemble, []Group{
+				{Hull: UpTo(-5), Members: []int{0}},
+				{Hull: Span{PosInf - 1, PosInf - 1}, Members: []int{1}},
+			})
+		})
+
+		Convey("Reversed spans should be normalized before grouping", func() {
+			So(Cluster([]Span{{10, 1}, {5, 5}}), ShouldResemble, []Group{
+				{Hull: Span{1, 10}, Members: []int{0, 1}},
+			})
+			So(Cluster([]Span{{20, 15}, {3, 12}}), ShouldResemble, []Group{
+				{Hull: Span{3, 12}, Members: []int{1}},
+				{Hull: Span{15, 20}, Members: []int{0}},
+			})
+		})
+	})
+}
@@ -0,0 +1,135 @@
+package span
+
+import (
+	"errors"
+	"sort"
+)
+
+var ErrNegativeCount = errors.New("Count would drop below zero")
+
+// CountingSet is a multiset of integers built from spans. Each integer
+// has a count, and runs of equal counts are kept coalesced.
+type CountingSet struct {
+	runs []DepthSpan
+}
+
+func NewCountingSet() *CountingSet {
+	return &CountingSet{}
+}
+
+// Add raises the count of every integer in s by count
+func (cs *CountingSet) Add(s Span, count int) error {
+	if count < 0 {
+		return ErrInvalidSize
+	}
+
+	cs.apply(s.Normalize(), count)
+
+	return nil
+}
+
+// Remove lowers the count of every integer in s by count. If that would
+// take any count below zero the set is left unchanged.
+func (cs *CountingSet) Remove(s Span, count int) error {
+	if count < 0 {
+		return ErrInvalidSize
+	}
+
+	s = s.Normalize()
+
+	if count > 0 && cs.minCount(s) < count {
+		return ErrNegativeCount
+	}
+
+	cs.apply(s, -count)
+
+	return nil
+}
+
+// Count is how many times n has been added, less removals
+func (cs *CountingSet) Count(n int) int {
+
+	i := cs.search(n)
+	if i < len(cs.runs) && cs.runs[i].Contains(n) {
+		return cs.runs[i].Depth
+	}
+
+	return 0
+}
+
+// Support is the normalized multispan of integers with a non-zero count
+func (cs *CountingSet) Support() Multispan {
+
+	ms := make(Multispan, len(cs.runs))
+
+	for i, r := range cs.runs {
+		ms[i] = r.Span
+	}
+
+	return ms.NormalizeWithin(1)
+}
+
+// Runs returns the runs of equal, non-zero count in order
+func (cs *CountingSet) Runs() []DepthSpan {
+
+	runs := make([]DepthSpan, len(cs.runs))
+	copy(runs, cs.runs)
+
+	return runs
+}
+
+// apply adds delta across s and rebuilds the runs
+func (cs *CountingSet) apply(s Span, delta int) {
+
+	if delta == 0 {
+		return
+	}
+
+	bounds := make([]boundary, 0, 2*len(cs.runs)+2)
+
+	for _, r := range cs.runs {
+		bounds = append(bounds, boundary{at: r.Start, delta: r.Depth})
+		if r.End != PosInf {
+			bounds = append(bounds, boundary{at: r.End + 1, delta: -r.Depth})
+		}
+	}
+
+	bounds = append(bounds, boundary{at: s.Start, delta: delta})
+	if s.End != PosInf {
+		bounds = append(bounds, boundary{at: s.End + 1, delta: -delta})
+	}
+
+	cs.runs = sweep(bounds)
+}
+
+// minCount is the smallest count of any integer in s
+func (cs *CountingSet) minCount(s Span) int {
+
+	least := PosInf
+	next := s.Start
+
+	for i := cs.search(s.Start); i < len(cs.runs) && cs.runs[i].Start <= s.End; i++ {
+
+		r := cs.runs[i]
+		if r.Start > next {
+			// uncovered integers before this run
+			return 0
+		}
+
+		least = min(least, r.Depth)
+
+		if r.End >= s.End {
+			return least
+		}
+		next = r.End + 1
+	}
+
+	return 0
+}
+
+// search finds the first run ending at or after n
+func (cs *CountingSet) search(n int) int {
+	return sort.Search(len(cs.runs), func(i int) bool {
+		return cs.runs[i].End >= n
+	})
+}
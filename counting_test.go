@@ -0,0 +1,57 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestCountingSet(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a counting set with 1-10 added twice", t, func() {
+		cs := NewCountingSet()
+		So(cs.Add(Span{1, 10}, 1), ShouldBeNil)
+		So(cs.Add(Span{10, 1}, 1), ShouldBeNil)
+
+		Convey("Removing it once should leave it covered", func() {
+			So(cs.Remove(Span{1, 10}, 1), ShouldBeNil)
+			So(cs.Count(5), ShouldEqual, 1)
+			So(cs.Support(), ShouldResemble, Multispan{{1, 10}})
+		})
+
+		Convey("Removing it twice should empty the set", func() {
+			So(cs.Remove(Span{1, 10}, 2), ShouldBeNil)
+			So(cs.Count(5), ShouldEqual, 0)
+			So(cs.Support(), ShouldBeEmpty)
+			So(cs.Runs(), ShouldBeEmpty)
+		})
+
+		Convey("Overlapping additions should give runs of equal counts", func() {
+			So(cs.Add(Span{5, 15}, 3), ShouldBeNil)
+
+			So(cs.Runs(), ShouldResemble, []DepthSpan{
+				{Span{1, 4}, 2},
+				{Span{5, 10}, 5},
+				{Span{11, 15}, 3},
+			})
+			So(cs.Count(0), ShouldEqual, 0)
+			So(cs.Count(10), ShouldEqual, 5)
+			So(cs.Count(15), ShouldEqual, 3)
+			So(cs.Support(), ShouldResemble, Multispan{{1, 15}})
+
+			Convey("And removing the middle should coalesce the runs again", func() {
+				So(cs.Remove(Span{5, 10}, 3), ShouldBeNil)
+				So(cs.Runs(), ShouldResemble, []DepthSpan{
+					{Span{1, 10}, 2},
+					{Span{11, 15}, 3},
+				})
+			})
+		})
+
+		Convey("Removing more than was added should fail and change nothing", func() {
+			So(cs.Remove(Span{1, 10}, 3), ShouldEqual, ErrNegativeCount)
+			So(cs.Remove(Span{5, 11}, 1), ShouldEqual, ErrNegativeCount)
+			So(cs.Remove(Span{0, 1}, 1), ShouldEqual, ErrNegativeCount)
+			So(cs.Add(Span{1, 1}, -1), ShouldEqual, ErrInvalidSize)
+			So(cs.Runs(), ShouldResemble, []DepthSpan{{Span{1, 10}, 2}})
+		})
+	})
+}
@@ -0,0 +1,56 @@
+package span
+
+import (
+	"sort"
+)
+
+// MinimalCover picks as few candidates as possible whose union covers the
+// target, which must be normalized. It returns the chosen indexes in order
+// of position, along with the part of the target no candidate reaches.
+//
+// Candidates are taken greedily: at the first integer still to be covered,
+// choose the candidate starting at or before it that reaches furthest.
+func MinimalCover(candidates []Span, target Multispan) ([]int, Multispan) {
+
+	spans := make([]Span, len(candidates))
+	order := make([]int, len(candidates))
+	for i, c := range candidates {
+		spans[i] = c.Normalize()
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return spans[order[a]].Start < spans[order[b]].Start
+	})
+
+	union := append(Multispan{}, spans...).NormalizeWithin(1)
+
+	gaps := target.Subtract(union)
+	coverable := target.Intersect(union)
+
+	chosen := make([]int, 0)
+
+	pos, err := coverable.NextIn(NegInf)
+	best, next := -1, 0
+
+	for err == nil {
+
+		// the furthest reaching candidate starting at or before pos
+		for ; next < len(order) && spans[order[next]].Start <= pos; next++ {
+			if best < 0 || spans[order[next]].End > spans[best].End {
+				best = order[next]
+			}
+		}
+
+		chosen = append(chosen, best)
+
+		end := spans[best].End
+		if end == PosInf {
+			break
+		}
+
+		pos, err = coverable.NextIn(end + 1)
+	}
+
+	return chosen, gaps
+}
@@ -0,0 +1,48 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestMinimalCover(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given candidate backup segments", t, func() {
+		candidates := []Span{{0, 5}, {3, 12}, {4, 8}, {10, 20}, {13, 15}, {30, 40}, {35, 50}}
+
+		Convey("When the target can be covered", func() {
+			chosen, gaps := MinimalCover(candidates, Multispan{{2, 18}})
+
+			Convey("The fewest segments should be chosen", func() {
+				So(chosen, ShouldResemble, []int{0, 1, 3})
+				So(gaps, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the target spans several pieces", func() {
+			chosen, gaps := MinimalCover(candidates, Multispan{{4, 6}, {14, 14}, {36, 45}})
+
+			Convey("Only segments reaching the pieces should be chosen", func() {
+				So(chosen, ShouldResemble, []int{1, 3, 6})
+				So(gaps, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When part of the target is out of reach", func() {
+			chosen, gaps := MinimalCover(candidates, Multispan{{18, 32}, {60, 70}})
+
+			Convey("The remainder should be reported", func() {
+				So(chosen, ShouldResemble, []int{3, 5})
+				So(gaps, ShouldResemble, Multispan{{21, 29}, {60, 70}})
+			})
+		})
+
+		Convey("When nothing is wanted", func() {
+			chosen, gaps := MinimalCover(candidates, NewMultiSpan(0))
+
+			Convey("Nothing should be chosen", func() {
+				So(chosen, ShouldBeEmpty)
+				So(gaps, ShouldBeEmpty)
+			})
+		})
+	})
+}
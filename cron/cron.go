@@ -0,0 +1,445 @@
+// Package cron parses cron expressions into per-field multispans and
+// computes fire times from them.
+package cron
+
+import (
+	"time"
+
+	"github.com/gregb/span"
+)
+
+// Years searched before Next or Prev give up on a schedule that never fires
+const searchYears = 400
+
+const (
+	lastDay        = iota // L, L-n
+	lastWeekday           // LW
+	nearestWeekday        // nW
+	lastOfWeekday         // nL
+	nthWeekday            // n#k
+)
+
+// special is a day term whose days depend on the month
+type special struct {
+	kind int
+	n, k int
+}
+
+// Schedule is a parsed cron expression. Each field is a normalized
+// multispan over the field's domain; weekdays run from 0 (Sunday) to 6.
+type Schedule struct {
+	Seconds  span.Multispan
+	Minutes  span.Multispan
+	Hours    span.Multispan
+	Days     span.Multispan
+	Months   span.Multispan
+	Weekdays span.Multispan
+
+	anyDay, anyWeekday bool
+
+	daySpecials, weekdaySpecials []special
+}
+
+// Matches reports whether the schedule fires at t, to the second
+func (s *Schedule) Matches(t time.Time) bool {
+
+	y, mo, d := t.Date()
+	h, mi, sec := t.Clock()
+
+	return s.Months.Contains(int(mo)) &&
+		s.days(y, mo).Contains(d) &&
+		s.Hours.Contains(h) &&
+		s.Minutes.Contains(mi) &&
+		s.Seconds.Contains(sec)
+}
+
+// Next returns the first fire time strictly after t, or the zero time if
+// the schedule never fires. Wall clock times skipped by a daylight saving
+// change never fire, and those repeated by one fire once per occurrence.
+func (s *Schedule) Next(t time.Time) time.Time {
+
+	loc := t.Location()
+	from := t.Truncate(time.Second).Add(time.Second)
+
+	// in an autumn overlap, later instants can read earlier on the clock
+	w := wall(from).Add(-positive(offset(from) - offset(from.Add(maxFold))))
+
+	var best, limit time.Time
+
+	for {
+		if !best.IsZero() && w.After(limit) {
+			return best
+		}
+
+		var ok bool
+		if w, ok = s.nextWall(w); !ok {
+			return best
+		}
+
+		for _, u := range instants(w, loc) {
+			if u.Before(from) || !s.Matches(u) {
+				continue
+			}
+			if best.IsZero() || u.Before(best) {
+				best = u
+				limit = wall(u).Add(positive(offset(u.Add(-maxFold)) - offset(u)))
+			}
+		}
+
+		w = w.Add(time.Second)
+	}
+}
+
+// Prev returns the last fire time strictly before t, or the zero time if
+// the schedule never fires. Daylight saving changes are handled as in Next.
+func (s *Schedule) Prev(t time.Time) time.Time {
+
+	loc := t.Location()
+	from := t.Truncate(time.Second)
+	if !from.Before(t) {
+		from = from.Add(-time.Second)
+	}
+
+	// in an autumn overlap, earlier instants can read later on the clock
+	w := wall(from).Add(positive(offset(from.Add(-maxFold)) - offset(from)))
+
+	var best, limit time.Time
+
+	for {
+		if !best.IsZero() && w.Before(limit) {
+			return best
+		}
+
+		var ok bool
+		if w, ok = s.prevWall(w); !ok {
+			return best
+		}
+
+		for _, u := range instants(w, loc) {
+			if u.After(from) || !s.Matches(u) {
+				continue
+			}
+			if best.IsZero() || u.After(best) {
+				best = u
+				limit = wall(u).Add(-positive(offset(u) - offset(u.Add(maxFold))))
+			}
+		}
+
+		w = w.Add(-time.Second)
+	}
+}
+
+// nextWall returns the first wall clock time at or after w on which the
+// schedule fires. Wall clock times are held as UTC so the field arithmetic
+// never meets a daylight saving change.
+func (s *Schedule) nextWall(w time.Time) (time.Time, bool) {
+
+	y, mo, d := w.Date()
+	h, mi, sec := w.Clock()
+
+	for limit := y + searchYears; y <= limit; {
+
+		if mo > time.December {
+			mo = time.January
+			y++
+		}
+
+		if !s.Months.Contains(int(mo)) {
+			mo++
+			d, h, mi, sec = 1, 0, 0, 0
+			continue
+		}
+
+		nd, err := s.days(y, mo).NextIn(d)
+		if err != nil {
+			mo++
+			d, h, mi, sec = 1, 0, 0, 0
+			continue
+		}
+		if nd != d {
+			d, h, mi, sec = nd, 0, 0, 0
+		}
+
+		nh, err := s.Hours.NextIn(h)
+		if err != nil {
+			d, h, mi, sec = d+1, 0, 0, 0
+			continue
+		}
+		if nh != h {
+			h, mi, sec = nh, 0, 0
+		}
+
+		nmi, err := s.Minutes.NextIn(mi)
+		if err != nil {
+			h, mi, sec = h+1, 0, 0
+			continue
+		}
+		if nmi != mi {
+			mi, sec = nmi, 0
+		}
+
+		nsec, err := s.Seconds.NextIn(sec)
+		if err != nil {
+			mi, sec = mi+1, 0
+			continue
+		}
+
+		return time.Date(y, mo, d, h, mi, nsec, 0, time.UTC), true
+	}
+
+	return time.Time{}, false
+}
+
+// prevWall returns the last wall clock time at or before w on which the
+// schedule fires
+func (s *Schedule) prevWall(w time.Time) (time.Time, bool) {
+
+	y, mo, d := w.Date()
+	h, mi, sec := w.Clock()
+
+	for limit := y - searchYears; y >= limit; {
+
+		if mo < time.January {
+			mo = time.December
+			y--
+		}
+
+		if !s.Months.Contains(int(mo)) {
+			mo--
+			d, h, mi, sec = 31, 23, 59, 59
+			continue
+		}
+
+		pd, err := s.days(y, mo).PrevIn(d)
+		if err != nil {
+			mo--
+			d, h, mi, sec = 31, 23, 59, 59
+			continue
+		}
+		if pd != d {
+			d, h, mi, sec = pd, 23, 59, 59
+		}
+
+		ph, err := s.Hours.PrevIn(h)
+		if err != nil {
+			d, h, mi, sec = d-1, 23, 59, 59
+			continue
+		}
+		if ph != h {
+			h, mi, sec = ph, 59, 59
+		}
+
+		pmi, err := s.Minutes.PrevIn(mi)
+		if err != nil {
+			h, mi, sec = h-1, 59, 59
+			continue
+		}
+		if pmi != mi {
+			mi, sec = pmi, 59
+		}
+
+		psec, err := s.Seconds.PrevIn(sec)
+		if err != nil {
+			mi, sec = mi-1, 59
+			continue
+		}
+
+		return time.Date(y, mo, d, h, mi, psec, 0, time.UTC), true
+	}
+
+	return time.Time{}, false
+}
+
+// Iterator walks fire times in one direction
+type Iterator struct {
+	s       *Schedule
+	t       time.Time
+	forward bool
+}
+
+// Forward iterates over fire times after from
+func (s *Schedule) Forward(from time.Time) *Iterator {
+	return &Iterator{s: s, t: from, forward: true}
+}
+
+// Backward iterates over fire times before from, latest first
+func (s *Schedule) Backward(from time.Time) *Iterator {
+	return &Iterator{s: s, t: from}
+}
+
+// Next returns the next fire time, or the zero time once there are none
+func (it *Iterator) Next() time.Time {
+
+	if it.t.IsZero() {
+		return it.t
+	}
+
+	if it.forward {
+		it.t = it.s.Next(it.t)
+	} else {
+		it.t = it.s.Prev(it.t)
+	}
+
+	return it.t
+}
+
+// days returns the days of the given month on which the schedule fires.
+// As in Vixie cron, when neither the day nor the weekday field starts with
+// "*" a day matching either of them fires; otherwise a day must match both.
+func (s *Schedule) days(year int, month time.Month) span.Multispan {
+
+	n := daysIn(year, month)
+
+	if s.anyDay || s.anyWeekday {
+		return s.dayDays(year, month, n).Intersect(s.weekdayDays(year, month, n))
+	}
+
+	return s.dayDays(year, month, n).Insert(s.weekdayDays(year, month, n)...).Normalize()
+}
+
+// dayDays resolves the day of month field for one month
+func (s *Schedule) dayDays(year int, month time.Month, n int) span.Multispan {
+
+	ms := span.NewMultiSpan(len(s.Days) + len(s.daySpecials))
+
+	for _, d := range s.Days {
+		if d.Start <= n {
+			ms = append(ms, span.Span{Start: d.Start, End: minInt(d.End, n)})
+		}
+	}
+
+	for _, sp := range s.daySpecials {
+
+		d := 0
+
+		switch sp.kind {
+		case lastDay:
+			d = n - sp.n
+		case lastWeekday:
+			d = n
+			switch weekday(year, month, d) {
+			case time.Saturday:
+				d--
+			case time.Sunday:
+				d -= 2
+			}
+		case nearestWeekday:
+			if sp.n > n {
+				continue
+			}
+			d = sp.n
+			switch weekday(year, month, d) {
+			case time.Saturday:
+				if d == 1 {
+					d += 2
+				} else {
+					d--
+				}
+			case time.Sunday:
+				if d == n {
+					d -= 2
+				} else {
+					d++
+				}
+			}
+		}
+
+		if d >= 1 {
+			ms = ms.Insert(span.Span{Start: d, End: d})
+		}
+	}
+
+	return ms.Normalize()
+}
+
+// weekdayDays resolves the day of week field for one month
+func (s *Schedule) weekdayDays(year int, month time.Month, n int) span.Multispan {
+
+	ms := span.NewMultiSpan(0)
+	first := int(weekday(year, month, 1))
+
+	for d := 1; d <= n; d++ {
+		if s.Weekdays.Contains((first + d - 1) % 7) {
+			ms = append(ms, span.Span{Start: d, End: d})
+		}
+	}
+
+	for _, sp := range s.weekdaySpecials {
+
+		d := 0
+
+		switch sp.kind {
+		case lastOfWeekday:
+			d = n - (int(weekday(year, month, n))-sp.n+7)%7
+		case nthWeekday:
+			d = 1 + (sp.n-first+7)%7 + 7*(sp.k-1)
+		}
+
+		if d <= n {
+			ms = ms.Insert(span.Span{Start: d, End: d})
+		}
+	}
+
+	return ms.Normalize()
+}
+
+// maxFold bounds how far a zone's offset moves at a single transition
+const maxFold = 3 * time.Hour
+
+// wall returns the wall clock reading of t as a UTC time
+func wall(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	h, mi, sec := t.Clock()
+	return time.Date(y, mo, d, h, mi, sec, 0, time.UTC)
+}
+
+// instants returns the times in loc whose wall clock reads w, earliest
+// first. There are none in a spring gap and two in an autumn overlap.
+func instants(w time.Time, loc *time.Location) []time.Time {
+
+	near := time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), 0, loc)
+	ts := make([]time.Time, 0, 2)
+
+	for _, probe := range []time.Time{near.Add(-maxFold), near.Add(maxFold)} {
+		u := w.Add(-offset(probe)).In(loc)
+		if wall(u).Equal(w) && (len(ts) == 0 || !ts[0].Equal(u)) {
+			ts = append(ts, u)
+		}
+	}
+
+	if len(ts) == 2 && ts[1].Before(ts[0]) {
+		ts[0], ts[1] = ts[1], ts[0]
+	}
+
+	return ts
+}
+
+// offset is t's offset east of UTC
+func offset(t time.Time) time.Duration {
+	_, off := t.Zone()
+	return time.Duration(off) * time.Second
+}
+
+func positive(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+
+	return d
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func weekday(year int, month time.Month, day int) time.Weekday {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday()
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,180 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+import . "github.com/smartystreets/goconvey/convey"
+
+func at(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04:05", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestNext(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given plain schedules", t, func() {
+		every5, _ := Parse("*/5 * * * *")
+		workdays, _ := Parse("0 9 * * MON-FRI")
+		seconds, _ := Parse("*/20 * * * * *")
+
+		Convey("Next() should find the following fire time", func() {
+			So(every5.Next(at("2024-03-10 10:03:27")), ShouldResemble, at("2024-03-10 10:05:00"))
+			So(every5.Next(at("2024-03-10 10:05:00")), ShouldResemble, at("2024-03-10 10:10:00"))
+			So(every5.Next(at("2024-12-31 23:59:00")), ShouldResemble, at("2025-01-01 00:00:00"))
+
+			// 2024-03-08 is a Friday
+			So(workdays.Next(at("2024-03-08 09:00:00")), ShouldResemble, at("2024-03-11 09:00:00"))
+			So(seconds.Next(at("2024-03-10 10:03:41")), ShouldResemble, at("2024-03-10 10:03:59").Add(time.Second))
+		})
+
+		Convey("Prev() should find the preceding fire time", func() {
+			So(every5.Prev(at("2024-03-10 10:03:27")), ShouldResemble, at("2024-03-10 10:00:00"))
+			So(every5.Prev(at("2024-01-01 00:00:00")), ShouldResemble, at("2023-12-31 23:55:00"))
+			So(workdays.Prev(at("2024-03-11 08:00:00")), ShouldResemble, at("2024-03-08 09:00:00"))
+			So(seconds.Prev(at("2024-03-10 10:03:40")), ShouldResemble, at("2024-03-10 10:03:20"))
+		})
+	})
+
+	Convey("Given schedules with month dependent days", t, func() {
+		last, _ := Parse("0 0 L * *")
+		lastWeekday, _ := Parse("0 0 LW * *")
+		nearest, _ := Parse("0 0 1W * *")
+		lastFriday, _ := Parse("0 0 * * 5L")
+		secondTuesday, _ := Parse("0 0 * * 2#2")
+		leap, _ := Parse("0 0 29 2 *")
+
+		Convey("They should be resolved per month", func() {
+			So(last.Next(at("2024-02-10 00:00:00")), ShouldResemble, at("2024-02-29 00:00:00"))
+			So(last.Next(at("2024-04-10 00:00:00")), ShouldResemble, at("2024-04-30 00:00:00"))
+
+			// 2024-08-31 is a Saturday
+			So(lastWeekday.Next(at("2024-08-01 00:00:00")), ShouldResemble, at("2024-08-30 00:00:00"))
+
+			// 2024-06-01 is a Saturday
+			So(nearest.Next(at("2024-05-31 00:00:00")), ShouldResemble, at("2024-06-03 00:00:00"))
+
+			So(lastFriday.Next(at("2024-03-01 00:00:00")), ShouldResemble, at("2024-03-29 00:00:00"))
+			So(secondTuesday.Next(at("2024-03-01 00:00:00")), ShouldResemble, at("2024-03-12 00:00:00"))
+
+			So(leap.Next(at("2024-03-01 00:00:00")), ShouldResemble, at("2028-02-29 00:00:00"))
+			So(leap.Prev(at("2024-03-01 00:00:00")), ShouldResemble, at("2024-02-29 00:00:00"))
+		})
+	})
+
+	Convey("Given both day and weekday restricted", t, func() {
+		s, _ := Parse("0 0 13 * FRI")
+
+		Convey("Either should fire", func() {
+			// 2024-09-06 is a Friday
+			So(s.Next(at("2024-09-01 00:00:00")), ShouldResemble, at("2024-09-06 00:00:00"))
+			So(s.Next(at("2024-09-12 00:00:00")), ShouldResemble, at("2024-09-13 00:00:00"))
+			So(s.Matches(at("2024-09-20 00:00:00")), ShouldBeTrue)
+			So(s.Matches(at("2024-09-21 00:00:00")), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a stepped day field starting with a star", t, func() {
+		s, _ := Parse("0 0 */2 * MON")
+
+		Convey("Both fields should have to match", func() {
+			// 2024-09-09 is a Monday on an odd day
+			So(s.Next(at("2024-09-01 00:00:00")), ShouldResemble, at("2024-09-09 00:00:00"))
+			So(s.Matches(at("2024-09-02 00:00:00")), ShouldBeFalse)
+			So(s.Matches(at("2024-09-03 00:00:00")), ShouldBeFalse)
+			So(s.Matches(at("2024-09-23 00:00:00")), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given a schedule that never fires", t, func() {
+		s, _ := Parse("0 0 30 2 *")
+
+		Convey("Next() should return the zero time", func() {
+			So(s.Next(at("2024-01-01 00:00:00")).IsZero(), ShouldBeTrue)
+		})
+	})
+}
+
+func TestIterator(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given an hourly schedule", t, func() {
+		s, _ := Parse("30 * * * *")
+
+		Convey("Iterators should walk fire times in both directions", func() {
+			fwd := s.Forward(at("2024-03-10 10:45:00"))
+			So(fwd.Next(), ShouldResemble, at("2024-03-10 11:30:00"))
+			So(fwd.Next(), ShouldResemble, at("2024-03-10 12:30:00"))
+
+			back := s.Backward(at("2024-03-10 10:45:00"))
+			So(back.Next(), ShouldResemble, at("2024-03-10 10:30:00"))
+			So(back.Next(), ShouldResemble, at("2024-03-10 09:30:00"))
+		})
+	})
+}
+
+func TestDaylightSaving(t *testing.T) {
+
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no zone data:", err)
+	}
+
+	utc := func(s string) time.Time { return at(s).In(ny) }
+
+	// Only pass t into top-level Convey calls
+	Convey("Given schedules in a zone with daylight saving", t, func() {
+		every10, _ := Parse("*/10 * * * *")
+		early, _ := Parse("30 2 * * *")
+		late, _ := Parse("30 1 * * *")
+
+		Convey("Next() should stay strictly after t across the autumn overlap", func() {
+			// 01:45 EST, the second pass through 01:45 on 2024-11-03
+			So(every10.Next(utc("2024-11-03 06:45:00")).Equal(utc("2024-11-03 06:50:00")), ShouldBeTrue)
+
+			// 01:55 EDT is followed by 01:00 EST
+			So(every10.Next(utc("2024-11-03 05:55:00")).Equal(utc("2024-11-03 06:00:00")), ShouldBeTrue)
+			So(every10.Next(utc("2024-11-03 05:35:00")).Equal(utc("2024-11-03 05:40:00")), ShouldBeTrue)
+
+			// a repeated wall clock time fires on each pass
+			first := late.Next(utc("2024-11-03 04:00:00"))
+			So(first.Equal(utc("2024-11-03 05:30:00")), ShouldBeTrue)
+			So(late.Next(first).Equal(utc("2024-11-03 06:30:00")), ShouldBeTrue)
+		})
+
+		Convey("Next() should skip wall clock times lost in spring", func() {
+			n := early.Next(utc("2024-03-10 05:00:00"))
+			So(n.Equal(utc("2024-03-11 06:30:00")), ShouldBeTrue)
+			So(early.Matches(n), ShouldBeTrue)
+		})
+
+		Convey("Prev() should stay strictly before t across the overlap", func() {
+			So(every10.Prev(utc("2024-11-03 06:05:00")).Equal(utc("2024-11-03 06:00:00")), ShouldBeTrue)
+			So(every10.Prev(utc("2024-11-03 06:00:00")).Equal(utc("2024-11-03 05:50:00")), ShouldBeTrue)
+			So(every10.Prev(utc("2024-11-03 05:52:00")).Equal(utc("2024-11-03 05:50:00")), ShouldBeTrue)
+			So(early.Prev(utc("2024-03-11 00:00:00")).Equal(utc("2024-03-09 07:30:00")), ShouldBeTrue)
+		})
+
+		Convey("Iterators should visit each instant once, in order", func() {
+			fwd := every10.Forward(utc("2024-11-03 05:35:00"))
+			prev := fwd.Next()
+			for i := 0; i < 20; i++ {
+				n := fwd.Next()
+				So(n.Sub(prev), ShouldEqual, 10*time.Minute)
+				prev = n
+			}
+
+			back := every10.Backward(utc("2024-11-03 07:05:00"))
+			prev = back.Next()
+			for i := 0; i < 20; i++ {
+				n := back.Next()
+				So(prev.Sub(n), ShouldEqual, 10*time.Minute)
+				prev = n
+			}
+		})
+	})
+}
@@ -0,0 +1,267 @@
+package cron
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gregb/span"
+)
+
+var ErrFieldCount = errors.New("Cron expression must have 5 or 6 fields")
+var ErrRange = errors.New("Cron value out of range")
+var ErrSyntax = errors.New("Invalid cron field")
+
+// domain and optional names for one cron field
+type field struct {
+	min, max int
+	names    []string
+}
+
+var (
+	secondField  = field{min: 0, max: 59}
+	minuteField  = field{min: 0, max: 59}
+	hourField    = field{min: 0, max: 23}
+	dayField     = field{min: 1, max: 31}
+	monthField   = field{min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	weekdayField = field{min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}
+)
+
+// Parse reads a 5 field (minute hour day month weekday) or 6 field
+// (second minute hour day month weekday) cron expression.
+//
+// Fields accept "*", "?", numbers, names, ranges, lists and steps ("*/5",
+// "1-5", "MON-FRI", "0,30", "10/15"). The day of month also accepts "L",
+// "L-n", "LW" and "nW"; the day of week accepts "nL" and "n#k".
+func Parse(expr string) (*Schedule, error) {
+
+	fields := strings.Fields(expr)
+
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, ErrFieldCount
+	}
+
+	s := &Schedule{}
+
+	var err error
+
+	if s.Seconds, err = secondField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if s.Minutes, err = minuteField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if s.Hours, err = hourField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if s.Months, err = monthField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+
+	s.anyDay = isAny(fields[3])
+	if s.Days, s.daySpecials, err = parseDays(fields[3]); err != nil {
+		return nil, err
+	}
+
+	s.anyWeekday = isAny(fields[5])
+	if s.Weekdays, s.weekdaySpecials, err = parseWeekdays(fields[5]); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// isAny reports whether a day or weekday field counts as unrestricted for
+// combining the two, which in Vixie cron is any field starting with "*"
+func isAny(f string) bool {
+	return strings.HasPrefix(f, "*") || f == "?"
+}
+
+// parse turns a plain field (no L, W or #) into a normalized multispan
+func (f field) parse(s string) (span.Multispan, error) {
+
+	ms := span.NewMultiSpan(0)
+
+	for _, item := range strings.Split(s, ",") {
+
+		ss, err := f.parseItem(item)
+		if err != nil {
+			return nil, err
+		}
+
+		ms = ms.Insert(ss.Multispan()...)
+	}
+
+	return ms.Normalize(), nil
+}
+
+func (f field) parseItem(item string) (span.StridedSpan, error) {
+
+	rng, step := item, ""
+	if i := strings.IndexByte(item, '/'); i >= 0 {
+		rng, step = item[:i], item[i+1:]
+	}
+
+	var start, end int
+	var err error
+
+	switch {
+	case rng == "*" || rng == "?":
+		start, end = f.min, f.max
+
+	case strings.IndexByte(rng, '-') > 0:
+		i := strings.IndexByte(rng, '-')
+		if start, err = f.value(rng[:i]); err != nil {
+			return span.StridedSpan{}, err
+		}
+		if end, err = f.value(rng[i+1:]); err != nil {
+			return span.StridedSpan{}, err
+		}
+		if end < start {
+			return span.StridedSpan{}, ErrRange
+		}
+
+	default:
+		if start, err = f.value(rng); err != nil {
+			return span.StridedSpan{}, err
+		}
+		end = start
+		if step != "" {
+			// "10/15" runs from 10 to the end of the field
+			end = f.max
+		}
+	}
+
+	n := 1
+	if step != "" {
+		if n, err = strconv.Atoi(step); err != nil {
+			return span.StridedSpan{}, ErrSyntax
+		}
+	}
+
+	ss, err := span.NewStridedSpan(start, end, n)
+	if err != nil {
+		return span.StridedSpan{}, ErrSyntax
+	}
+
+	return ss, nil
+}
+
+// value reads a number or name, checking it against the field's domain
+func (f field) value(s string) (int, error) {
+
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return i + f.min, nil
+		}
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, ErrSyntax
+	}
+
+	if n < f.min || n > f.max {
+		return 0, ErrRange
+	}
+
+	return n, nil
+}
+
+func parseDays(s string) (span.Multispan, []special, error) {
+
+	var plain []string
+	var specials []special
+
+	for _, item := range strings.Split(s, ",") {
+
+		var sp special
+		var err error
+
+		switch {
+		case item == "L":
+			sp = special{kind: lastDay}
+		case strings.HasPrefix(item, "L-"):
+			sp.kind = lastDay
+			if sp.n, err = strconv.Atoi(item[2:]); err != nil || sp.n < 0 || sp.n > 30 {
+				return nil, nil, ErrSyntax
+			}
+		case item == "LW":
+			sp = special{kind: lastWeekday}
+		case strings.HasSuffix(item, "W"):
+			sp.kind = nearestWeekday
+			if sp.n, err = dayField.value(item[:len(item)-1]); err != nil {
+				return nil, nil, err
+			}
+		default:
+			plain = append(plain, item)
+			continue
+		}
+
+		specials = append(specials, sp)
+	}
+
+	ms, err := dayField.parsePlain(plain)
+
+	return ms, specials, err
+}
+
+func parseWeekdays(s string) (span.Multispan, []special, error) {
+
+	var plain []string
+	var specials []special
+
+	for _, item := range strings.Split(s, ",") {
+
+		var sp special
+		var err error
+
+		switch {
+		case len(item) > 1 && strings.HasSuffix(item, "L"):
+			sp.kind = lastOfWeekday
+			if sp.n, err = weekdayField.value(item[:len(item)-1]); err != nil {
+				return nil, nil, err
+			}
+		case strings.IndexByte(item, '#') > 0:
+			i := strings.IndexByte(item, '#')
+			sp.kind = nthWeekday
+			if sp.n, err = weekdayField.value(item[:i]); err != nil {
+				return nil, nil, err
+			}
+			if sp.k, err = strconv.Atoi(item[i+1:]); err != nil || sp.k < 1 || sp.k > 5 {
+				return nil, nil, ErrRange
+			}
+		default:
+			plain = append(plain, item)
+			continue
+		}
+
+		sp.n %= 7
+		specials = append(specials, sp)
+	}
+
+	ms, err := weekdayField.parsePlain(plain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 7 is another name for Sunday
+	if ms.Contains(7) {
+		ms = ms.Insert(span.Span{Start: 0, End: 0}).Normalize()
+	}
+
+	return ms, specials, nil
+}
+
+func (f field) parsePlain(items []string) (span.Multispan, error) {
+
+	if len(items) == 0 {
+		return span.NewMultiSpan(0), nil
+	}
+
+	return f.parse(strings.Join(items, ","))
+}
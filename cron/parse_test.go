@@ -0,0 +1,59 @@
+package cron
+
+import "testing"
+import "github.com/gregb/span"
+import . "github.com/smartystreets/goconvey/convey"
+
+func ms(s string) span.Multispan {
+	m, err := span.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func TestParseFields(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a 5 field expression with steps, ranges, lists and names", t, func() {
+		s, err := Parse("*/15 9-17 1,15 JAN-MAR MON-FRI")
+
+		Convey("Each field should parse into a normalized multispan", func() {
+			So(err, ShouldBeNil)
+			So(s.Seconds, ShouldResemble, ms("0"))
+			So(s.Minutes, ShouldResemble, ms("0,15,30,45"))
+			So(s.Hours, ShouldResemble, ms("9-17"))
+			So(s.Days, ShouldResemble, ms("1,15"))
+			So(s.Months, ShouldResemble, ms("1-3"))
+			So(s.Weekdays, ShouldResemble, ms("1-5"))
+		})
+	})
+
+	Convey("Given a 6 field expression", t, func() {
+		s, err := Parse("30 0 12 * * 7")
+
+		Convey("The first field should be seconds and 7 should mean Sunday", func() {
+			So(err, ShouldBeNil)
+			So(s.Seconds, ShouldResemble, ms("30"))
+			So(s.Weekdays.Contains(0), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given malformed expressions", t, func() {
+		_, e1 := Parse("* * * *")
+		_, e2 := Parse("60 * * * *")
+		_, e3 := Parse("* * * * FOO")
+		_, e4 := Parse("*/0 * * * *")
+		_, e5 := Parse("* * * * 1#6")
+		_, e6 := Parse("* 17-9 * * *")
+
+		Convey("Parse should report them", func() {
+			So(e1, ShouldEqual, ErrFieldCount)
+			So(e2, ShouldEqual, ErrRange)
+			So(e3, ShouldEqual, ErrSyntax)
+			So(e4, ShouldEqual, ErrSyntax)
+			So(e5, ShouldEqual, ErrRange)
+			So(e6, ShouldEqual, ErrRange)
+		})
+	})
+}
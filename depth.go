@@ -0,0 +1,113 @@
+package span
+
+import (
+	"sort"
+)
+
+// DepthSpan is a run of integers covered by the same number of spans
+type DepthSpan struct {
+	Span
+	Depth int
+}
+
+// boundary is a sweep line event: depth changes by delta from at onwards
+type boundary struct {
+	at    int
+	delta int
+}
+
+// Depth sweeps the spans, which need not be normalized, and returns the
+// coverage count as a step function: runs of equal, non-zero depth in
+// order. Integers no span covers are left out.
+func (ms Multispan) Depth() []DepthSpan {
+
+	bounds := make([]boundary, 0, 2*len(ms))
+
+	for _, s := range ms {
+		s = s.Normalize()
+
+		bounds = append(bounds, boundary{at: s.Start, delta: 1})
+		if s.End != PosInf {
+			bounds = append(bounds, boundary{at: s.End + 1, delta: -1})
+		}
+	}
+
+	return sweep(bounds)
+}
+
+// sweep turns depth changes into runs of equal, non-zero depth
+func sweep(bounds []boundary) []DepthSpan {
+
+	sort.Slice(bounds, func(i, j int) bool {
+		return bounds[i].at < bounds[j].at
+	})
+
+	runs := make([]DepthSpan, 0, len(bounds))
+
+	depth := 0
+	for i := 0; i < len(bounds); {
+
+		at := bounds[i].at
+		for i < len(bounds) && bounds[i].at == at {
+			depth += bounds[i].delta
+			i++
+		}
+
+		if depth == 0 {
+			continue
+		}
+
+		end := PosInf
+		if i < len(bounds) {
+			end = bounds[i].at - 1
+		}
+
+		if n := len(runs); n > 0 && runs[n-1].Depth == depth && runs[n-1].End == at-1 {
+			runs[n-1].End = end
+			continue
+		}
+
+		runs = append(runs, DepthSpan{Span: Span{Start: at, End: end}, Depth: depth})
+	}
+
+	return runs
+}
+
+// MaxDepth is the largest number of spans covering any one integer, and
+// the integers where that many spans meet.
+func (ms Multispan) MaxDepth() (int, Multispan) {
+
+	best := 0
+	where := NewMultiSpan(0)
+
+	for _, d := range ms.Depth() {
+		switch {
+		case d.Depth > best:
+			best = d.Depth
+			where = Multispan{d.Span}
+		case d.Depth == best:
+			where = append(where, d.Span)
+		}
+	}
+
+	return best, where
+}
+
+// CoveredAtLeast is the normalized multispan of integers covered by k or
+// more spans. k must be at least one.
+func (ms Multispan) CoveredAtLeast(k int) (Multispan, error) {
+
+	if k < 1 {
+		return nil, ErrInvalidSize
+	}
+
+	out := NewMultiSpan(0)
+
+	for _, d := range ms.Depth() {
+		if d.Depth >= k {
+			out = append(out, d.Span)
+		}
+	}
+
+	return out.NormalizeWithin(1), nil
+}
@@ -0,0 +1,60 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestDepth(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given overlapping reservations", t, func() {
+		ms := Multispan{{1, 10}, {5, 15}, {8, 9}, {16, 20}, {30, 30}, From(40), {45, 50}}
+
+		//    000000000111111111122
+		//    123456789012345678901
+		// 1: **********-----------
+		// 2: ----***********------
+		// 3: -------**------------
+		// 4: ---------------*****-
+
+		Convey("Depth() should return the coverage step function", func() {
+			So(ms.Depth(), ShouldResemble, []DepthSpan{
+				{Span{1, 4}, 1},
+				{Span{5, 7}, 2},
+				{Span{8, 9}, 3},
+				{Span{10, 10}, 2},
+				{Span{11, 20}, 1},
+				{Span{30, 30}, 1},
+				{Span{40, 44}, 1},
+				{Span{45, 50}, 2},
+				{From(51), 1},
+			})
+		})
+
+		Convey("MaxDepth() should report the peak and where it occurs", func() {
+			d, where := ms.MaxDepth()
+			So(d, ShouldEqual, 3)
+			So(where, ShouldResemble, Multispan{{8, 9}})
+
+			d, where = Multispan{{1, 2}, {1, 2}, {5, 6}, {6, 8}}.MaxDepth()
+			So(d, ShouldEqual, 2)
+			So(where, ShouldResemble, Multispan{{1, 2}, {6, 6}})
+		})
+
+		Convey("CoveredAtLeast() should keep integers covered often enough", func() {
+			ok := func(out Multispan, err error) Multispan {
+				So(err, ShouldBeNil)
+				return out
+			}
+
+			So(ok(ms.CoveredAtLeast(2)), ShouldResemble, Multispan{{5, 10}, {45, 50}})
+			So(ok(ms.CoveredAtLeast(3)), ShouldResemble, Multispan{{8, 9}})
+			So(ok(ms.CoveredAtLeast(4)), ShouldBeEmpty)
+			So(ok(ms.CoveredAtLeast(1)), ShouldResemble, Multispan{{1, 20}, {30, 30}, From(40)})
+
+			_, err := ms.CoveredAtLeast(0)
+			So(err, ShouldEqual, ErrInvalidSize)
+			_, err = ms.CoveredAtLeast(-1)
+			So(err, ShouldEqual, ErrInvalidSize)
+		})
+	})
+}
@@ -0,0 +1,133 @@
+package span
+
+import (
+	"sort"
+)
+
+// LabeledSpan is a span carrying a caller's record. Key partitions spans
+// for joins; Label is whatever the caller needs to trace the span back.
+type LabeledSpan struct {
+	Span
+	Key   string
+	Label interface{}
+}
+
+// JoinFunc receives the indexes of an overlapping left and right span and
+// the region they share. In a left outer join r is -1 and overlap is Zero
+// for left spans that matched nothing.
+type JoinFunc func(l, r int, overlap Span)
+
+// JoinOptions adjust OverlapJoinWith
+type JoinOptions struct {
+	MinOverlap int  // pairs sharing fewer integers are skipped
+	LeftOuter  bool // report left spans with no match
+	ByKey      bool // only join spans with equal keys
+}
+
+// OverlapJoin calls fn for every overlapping pair of a left and a right
+// span, sweeping both in order of start. Pairs are reported by left span
+// in order of start, then by right span in order of start.
+func OverlapJoin(left, right []LabeledSpan, fn JoinFunc) {
+	OverlapJoinWith(left, right, JoinOptions{}, fn)
+}
+
+// OverlapJoinWith is OverlapJoin with options. With ByKey, partitions are
+// joined one at a time in key order.
+func OverlapJoinWith(left, right []LabeledSpan, opts JoinOptions, fn JoinFunc) {
+
+	if !opts.ByKey {
+		joinSweep(left, right, byStart(left, indexes(len(left))), byStart(right, indexes(len(right))), opts, fn)
+		return
+	}
+
+	lparts := partition(left)
+	rparts := partition(right)
+
+	keys := make([]string, 0, len(lparts))
+	for k := range lparts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// a key missing on the right has no partition, so its left spans only
+	// show up as left outer rows
+	for _, k := range keys {
+		joinSweep(left, right, byStart(left, lparts[k]), byStart(right, rparts[k]), opts, fn)
+	}
+}
+
+func joinSweep(left, right []LabeledSpan, lorder, rorder []int, opts JoinOptions, fn JoinFunc) {
+
+	active := make([]int, 0)
+	next := 0
+
+	for _, l := range lorder {
+
+		ls := left[l].Normalize()
+
+		// bring in right spans starting by the end of this one
+		for ; next < len(rorder) && right[rorder[next]].Normalize().Start <= ls.End; next++ {
+			active = append(active, rorder[next])
+		}
+
+		// drop right spans ending before this one starts, as every later
+		// left span starts later still
+		kept := active[:0]
+		for _, r := range active {
+			if right[r].Normalize().End >= ls.Start {
+				kept = append(kept, r)
+			}
+		}
+		active = kept
+
+		matched := false
+
+		for _, r := range active {
+
+			overlap, err := ls.Overlap(right[r].Normalize())
+			if err != nil || overlap.Len() < opts.MinOverlap {
+				continue
+			}
+
+			fn(l, r, overlap)
+			matched = true
+		}
+
+		if opts.LeftOuter && !matched {
+			fn(l, -1, Zero)
+		}
+	}
+}
+
+// byStart orders the given indexes by the start of their spans
+func byStart(spans []LabeledSpan, idx []int) []int {
+
+	sort.SliceStable(idx, func(a, b int) bool {
+		return spans[idx[a]].Normalize().Start < spans[idx[b]].Normalize().Start
+	})
+
+	return idx
+}
+
+// indexes returns 0 through n-1
+func indexes(n int) []int {
+
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+
+	return idx
+}
+
+// partition groups span indexes by key
+func partition(spans []LabeledSpan) map[string][]int {
+
+	parts := make(map[string][]int)
+
+	for i, s := range spans {
+		parts[s.Key] = append(parts[s.Key], i)
+	}
+
+	return parts
+}
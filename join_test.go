@@ -0,0 +1,82 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+type joined struct {
+	l, r    int
+	overlap Span
+}
+
+func collect(out *[]joined) JoinFunc {
+	return func(l, r int, overlap Span) {
+		*out = append(*out, joined{l, r, overlap})
+	}
+}
+
+func TestOverlapJoin(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given events and maintenance windows", t, func() {
+		events := []LabeledSpan{
+			{Span: Span{10, 20}, Key: "db", Label: "backup"},
+			{Span: Span{1, 3}, Key: "web", Label: "deploy"},
+			{Span: Span{30, 40}, Key: "db", Label: "vacuum"},
+			{Span: Span{5, 12}, Key: "web", Label: "restart"},
+		}
+		windows := []LabeledSpan{
+			{Span: Span{0, 5}, Key: "web"},
+			{Span: Span{12, 32}, Key: "db"},
+			{Span: Span{15, 16}, Key: "web"},
+		}
+
+		Convey("OverlapJoin() should report every overlapping pair", func() {
+			var out []joined
+			OverlapJoin(events, windows, collect(&out))
+
+			So(out, ShouldResemble, []joined{
+				{1, 0, Span{1, 3}},
+				{3, 0, Span{5, 5}},
+				{3, 1, Span{12, 12}},
+				{0, 1, Span{12, 20}},
+				{0, 2, Span{15, 16}},
+				{2, 1, Span{30, 32}},
+			})
+		})
+
+		Convey("A minimum overlap should drop small pairs", func() {
+			var out []joined
+			OverlapJoinWith(events, windows, JoinOptions{MinOverlap: 3}, collect(&out))
+
+			So(out, ShouldResemble, []joined{
+				{1, 0, Span{1, 3}},
+				{0, 1, Span{12, 20}},
+				{2, 1, Span{30, 32}},
+			})
+		})
+
+		Convey("Partitioning by key and left outer should report unmatched events", func() {
+			var out []joined
+			OverlapJoinWith(events, windows, JoinOptions{ByKey: true, LeftOuter: true, MinOverlap: 2}, collect(&out))
+
+			So(out, ShouldResemble, []joined{
+				{0, 1, Span{12, 20}},
+				{2, 1, Span{30, 32}},
+				{1, 0, Span{1, 3}},
+				{3, -1, Zero},
+			})
+		})
+
+		Convey("A key present on only one side should match nothing", func() {
+			l := []LabeledSpan{{Span: Span{1, 10}, Key: "a"}}
+			r := []LabeledSpan{{Span: Span{1, 10}, Key: "b"}}
+
+			var inner, outer []joined
+			OverlapJoinWith(l, r, JoinOptions{ByKey: true}, collect(&inner))
+			OverlapJoinWith(l, r, JoinOptions{ByKey: true, LeftOuter: true}, collect(&outer))
+
+			So(inner, ShouldBeEmpty)
+			So(outer, ShouldResemble, []joined{{0, -1, Zero}})
+		})
+	})
+}
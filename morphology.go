@@ -0,0 +1,108 @@
+package span
+
+import (
+	"sort"
+)
+
+// NormalizeWithin is Normalize with a tolerance: spans whose Gap is at
+// most k are merged as well as overlapping ones. NormalizeWithin(0) is
+// Normalize, and NormalizeWithin(1) also merges adjacent spans.
+func (ms Multispan) NormalizeWithin(k int) Multispan {
+
+	if len(ms) <= 1 {
+		return ms
+	}
+
+	sort.Sort(ms)
+
+	spans := make([]Span, 0, len(ms))
+
+	left := ms[0]
+	for _, right := range ms[1:] {
+
+		if gap, err := left.Gap(right); err == nil && distance(gap.Start, gap.End) > k {
+			spans = append(spans, left)
+			left = right
+			continue
+		}
+
+		left.End = max(left.End, right.End)
+	}
+
+	return append(spans, left)
+}
+
+// Dilate pads every span by k on both sides and merges the result
+func (ms Multispan) Dilate(k int) (Multispan, error) {
+
+	if k < 0 {
+		return nil, ErrInvalidSize
+	}
+
+	out := make(Multispan, len(ms))
+
+	for i, s := range ms {
+		out[i] = Span{Start: shift(s.Start, -k), End: shift(s.End, k)}
+	}
+
+	return out.NormalizeWithin(1), nil
+}
+
+// Erode shrinks every span by k on both sides, dropping spans that
+// vanish. Touching spans are merged first, so this is erosion of the set
+// of covered integers.
+func (ms Multispan) Erode(k int) (Multispan, error) {
+
+	if k < 0 {
+		return nil, ErrInvalidSize
+	}
+
+	in := make(Multispan, len(ms))
+	copy(in, ms)
+	in = in.NormalizeWithin(1)
+
+	out := NewMultiSpan(len(in))
+
+	for _, s := range in {
+		t := Span{Start: shift(s.Start, k), End: shift(s.End, -k)}
+		if t.Start <= t.End {
+			out = append(out, t)
+		}
+	}
+
+	return out, nil
+}
+
+// Opening erodes then dilates by k, removing spans shorter than 2k+1
+// integers and leaving the rest alone.
+func (ms Multispan) Opening(k int) (Multispan, error) {
+
+	eroded, err := ms.Erode(k)
+	if err != nil {
+		return nil, err
+	}
+
+	return eroded.Dilate(k)
+}
+
+// Closing dilates then erodes by k, filling gaps of up to 2k integers.
+func (ms Multispan) Closing(k int) (Multispan, error) {
+
+	dilated, err := ms.Dilate(k)
+	if err != nil {
+		return nil, err
+	}
+
+	return dilated.Erode(k)
+}
+
+// distance is b-a for a <= b, or PosInf if that is too large for an int
+func distance(a, b int) int {
+
+	n := uint(b) - uint(a)
+	if n > uint(PosInf) {
+		return PosInf
+	}
+
+	return int(n)
+}
@@ -0,0 +1,74 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestNormalizeWithin(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given detection windows with small gaps", t, func() {
+
+		Convey("NormalizeWithin() should merge spans whose gap is within k", func() {
+			ms := Multispan{{1, 4}, {3, 6}, {7, 8}, {10, 12}, {20, 21}}
+			So(ms.NormalizeWithin(0), ShouldResemble, Multispan{{1, 6}, {7, 8}, {10, 12}, {20, 21}})
+
+			ms = Multispan{{1, 4}, {3, 6}, {7, 8}, {10, 12}, {20, 21}}
+			So(ms.NormalizeWithin(1), ShouldResemble, Multispan{{1, 8}, {10, 12}, {20, 21}})
+
+			ms = Multispan{{20, 21}, {1, 4}, {3, 6}, {7, 8}, {10, 12}}
+			So(ms.NormalizeWithin(2), ShouldResemble, Multispan{{1, 12}, {20, 21}})
+		})
+
+		Convey("NormalizeWithin() should not overflow on gaps wider than an int", func() {
+			ms := Multispan{UpTo(-5), {PosInf - 1, PosInf - 1}}
+			So(ms.NormalizeWithin(3), ShouldResemble, Multispan{UpTo(-5), {PosInf - 1, PosInf - 1}})
+			So(ms.NormalizeWithin(PosInf), ShouldResemble, Multispan{UpTo(PosInf - 1)})
+		})
+	})
+}
+
+func TestDilateErode(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a normalized multispan", t, func() {
+		ms := Multispan{{1, 1}, {4, 4}, {10, 20}, From(30)}
+
+		ok := func(out Multispan, err error) Multispan {
+			So(err, ShouldBeNil)
+			return out
+		}
+
+		Convey("Dilate() should pad and merge", func() {
+			So(ok(ms.Dilate(1)), ShouldResemble, Multispan{{0, 5}, {9, 21}, From(29)})
+			So(ok(ms.Dilate(0)), ShouldResemble, ms)
+		})
+
+		Convey("Erode() should shrink and drop vanished spans", func() {
+			So(ok(ms.Erode(1)), ShouldResemble, Multispan{{11, 19}, From(31)})
+			So(ok(ms.Erode(6)), ShouldResemble, Multispan{From(36)})
+			So(ok(Multispan{{1, 3}, {4, 6}}.Erode(1)), ShouldResemble, Multispan{{2, 5}})
+		})
+
+		Convey("Opening() should drop short spans and keep the rest", func() {
+			So(ok(ms.Opening(1)), ShouldResemble, Multispan{{10, 20}, From(30)})
+		})
+
+		Convey("Closing() should fill short gaps", func() {
+			So(ok(ms.Closing(1)), ShouldResemble, Multispan{{1, 4}, {10, 20}, From(30)})
+			So(ok(ms.Closing(4)), ShouldResemble, Multispan{{1, 20}, From(30)})
+			So(ok(ms.Closing(5)), ShouldResemble, Multispan{From(1)})
+		})
+
+		Convey("A negative k should be rejected", func() {
+			_, e1 := ms.Dilate(-6)
+			_, e2 := ms.Erode(-2)
+			_, e3 := ms.Opening(-1)
+			_, e4 := ms.Closing(-1)
+
+			So(e1, ShouldEqual, ErrInvalidSize)
+			So(e2, ShouldEqual, ErrInvalidSize)
+			So(e3, ShouldEqual, ErrInvalidSize)
+			So(e4, ShouldEqual, ErrInvalidSize)
+		})
+	})
+}
@@ -1,9 +1,21 @@
 package span
 
 import (
+	"errors"
 	"sort"
+	"strconv"
+	"strings"
 )
 
+var ErrSyntax = errors.New("Invalid span syntax")
+var ErrUnboundedStride = errors.New("Stride keyword needs a bounded range")
+var ErrRange = errors.New("Number out of range")
+var ErrStrideTooLong = errors.New("Stride has too many members")
+
+// MaxStrideLen caps how many points a single stride element may expand
+// to while parsing, so a short string can't demand unbounded memory
+var MaxStrideLen = 1 << 16
+
 type Multispan []Span
 
 func NewMultiSpan(cap int) Multispan {
@@ -50,7 +62,7 @@ func (ms Multispan) Insert(spans ...Span) Multispan {
 
 func (ms Multispan) Normalize() Multispan {
 
-	if len(ms) == 1 {
+	if len(ms) <= 1 {
 		return ms
 	}
 
@@ -84,10 +96,49 @@ func (ms Multispan) Normalize() Multispan {
 	return spans
 }
 
+// Contains reports whether n falls in any span. The multispan must be
+// normalized.
+func (ms Multispan) Contains(n int) bool {
+
+	i := ms.search(n)
+
+	return i < len(ms) && ms[i].Contains(n)
+}
+
+// Size is the number of integers covered, or PosInf if any span is
+// unbounded or there are more than an int can count. The multispan must be
+// normalized.
+func (ms Multispan) Size() int {
+
+	n := 0
+
+	for _, s := range ms {
+		l := s.Len()
+		if l > PosInf-n {
+			return PosInf
+		}
+		n += l
+	}
+
+	return n
+}
+
 func (ms Multispan) Get(i int) Span {
 	return ms[i]
 }
 
+// String formats the multispan in the notation read by Parse
+func (ms Multispan) String() string {
+
+	parts := make([]string, len(ms))
+
+	for i, s := range ms {
+		parts[i] = s.String()
+	}
+
+	return strings.Join(parts, ",")
+}
+
 // implements sort.Interface
 // Len is the number of elements in the collection.
 func (ms Multispan) Len() int {
@@ -107,59 +158,204 @@ func (ms Multispan) Swap(i, j int) {
 	ms[i], ms[j] = ms[j], ms[i]
 }
 
+// Parse reads a comma separated list of spans such as "1,3-5,10-20/2".
+// A range may leave either end open ("5-", "-10"), and "*" covers every
+// integer. Negative numbers go in parentheses: "(-5)-3", "-(-1)". A bounded range may be followed by a step, written "/n" or
+// ":n", or by the keywords "even" or "odd" ("1-20/odd"). Bare "even" and
+// "odd" need a domain to expand into; use ParseWithin for those.
 func Parse(s string) (Multispan, error) {
+	return parse(s, nil)
+}
+
+// ParseWithin is like Parse, but open ends and bare "even" and "odd"
+// elements are resolved against the given domain.
+func ParseWithin(s string, domain Span) (Multispan, error) {
+	domain = domain.Normalize()
+	return parse(s, &domain)
+}
+
+func parse(s string, domain *Span) (Multispan, error) {
 
 	ms := Multispan{}
 
-	bytes := []byte(s)
+	if len(s) == 0 {
+		return ms, nil
+	}
+
+	spans := make([]Span, 0, strings.Count(s, ",")+1)
 
-	n := -1
-	mark := 0
+	for _, elem := range strings.Split(s, ",") {
 
-	for i, b := range bytes {
+		ss, err := parseElement(strings.TrimSpace(elem), domain)
+		if err != nil {
+			return nil, err
+		}
 
-		if b == ',' {
-			t := atoi(bytes[mark:i])
+		if ss.Step > 1 && ss.Len() > MaxStrideLen {
+			return nil, ErrStrideTooLong
+		}
 
-			if n > 0 {
-				// there was already a parsed number here since the last comma
-				// so this is a range
-				ms = ms.Insert(Span{n, t})
-			} else {
-				// no other numbers since last comma
-				// this is a point range
-				ms = ms.Insert(Span{t, t})
-			}
+		spans = append(spans, ss.Multispan()...)
+	}
 
-			// reset n and mark the next spot
-			n = -1
-			mark = i + 1
+	return ms.Insert(spans...), nil
+}
+
+// parseElement reads a single element of the Parse grammar
+func parseElement(elem string, domain *Span) (StridedSpan, error) {
+
+	if elem == "even" || elem == "odd" {
+		if domain == nil {
+			return StridedSpan{}, ErrUnboundedStride
 		}
+		return parity(*domain, elem), nil
+	}
+
+	rng, step := elem, ""
+	if i := strings.IndexAny(elem, "/:"); i >= 0 {
+		rng, step = elem[:i], elem[i+1:]
+	}
+
+	s, err := parseRange(rng)
+	if err != nil {
+		return StridedSpan{}, err
+	}
+	s = s.Normalize()
 
-		if i == len(bytes)-1 {
-			t := atoi(bytes[mark : i+1])
-
-			if n > 0 {
-				// there was already a parsed number here since the last comma
-				// so this is a range
-				ms = ms.Insert(Span{n, t})
-				n = -1
-			} else {
-				// no other numbers since last comma
-				// this is a point range
-				ms = ms.Insert(Span{t, t})
+	if domain != nil {
+		if s.Start == NegInf {
+			s.Start = domain.Start
+		}
+		if s.End == PosInf {
+			s.End = domain.End
+		}
+	}
+
+	switch step {
+	case "":
+		return StridedSpan{Start: s.Start, End: s.End, Step: 1}, nil
+	case "even", "odd":
+		if !s.IsBounded() {
+			return StridedSpan{}, ErrUnboundedStride
+		}
+		return parity(s, step), nil
+	}
+
+	n, err := parseInt(step)
+	if err != nil {
+		return StridedSpan{}, err
+	}
+
+	return NewStridedSpan(s.Start, s.End, n)
+}
+
+// parseRange reads a single number, a range, an open ended range or "*".
+// The ends are left in the order written.
+func parseRange(rng string) (Span, error) {
+
+	if rng == "*" {
+		return Unbounded, nil
+	}
+
+	i := rangeDash(rng)
+
+	switch {
+	case i < 0:
+		n, err := parseNumber(rng)
+		return Span{n, n}, err
+	case i == 0:
+		end, err := parseNumber(rng[1:])
+		return UpTo(end), err
+	case i == len(rng)-1:
+		start, err := parseNumber(rng[:i])
+		return From(start), err
+	}
+
+	start, err := parseNumber(rng[:i])
+	if err != nil {
+		return Zero, err
+	}
+
+	end, err := parseNumber(rng[i+1:])
+	if err != nil {
+		return Zero, err
+	}
+
+	return Span{Start: start, End: end}, nil
+}
+
+// rangeDash finds the dash separating the ends of a range, skipping the
+// signs of parenthesized negative numbers
+func rangeDash(rng string) int {
+
+	depth := 0
+
+	for i := 0; i < len(rng); i++ {
+		switch rng[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '-':
+			if depth == 0 {
+				return i
 			}
 		}
+	}
+
+	return -1
+}
+
+// parseNumber reads an end of a range. Negative numbers are written in
+// parentheses, as in "(-5)", since a bare leading dash marks an open end.
+func parseNumber(s string) (int, error) {
+
+	if len(s) < 4 || s[0] != '(' || s[len(s)-1] != ')' {
+		return parseInt(s)
+	}
+
+	if s[1] != '-' {
+		return 0, ErrSyntax
+	}
+
+	digits := s[2 : len(s)-1]
+
+	n, err := parseInt(digits)
+	if err == ErrRange && strings.TrimLeft(digits, "0") == minInt[1:] {
+		return NegInf, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return -n, nil
+}
+
+// parseInt is atoi with the input checked first, rejecting anything
+// that isn't a plain decimal or would overflow an int
+func parseInt(s string) (int, error) {
 
-		if b == '-' {
-			n = atoi(bytes[mark:i])
-			mark = i + 1
+	if len(s) == 0 {
+		return 0, ErrSyntax
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, ErrSyntax
 		}
 	}
 
-	return ms, nil
+	digits := strings.TrimLeft(s, "0")
+	if len(digits) > len(maxInt) || len(digits) == len(maxInt) && digits > maxInt {
+		return 0, ErrRange
+	}
+
+	return atoi([]byte(s)), nil
 }
 
+// maxInt and minInt are PosInf and NegInf written out, for overflow checks
+var maxInt, minInt = strconv.Itoa(PosInf), strconv.Itoa(NegInf)
+
 // Dumb, fast string to int converter
 // Restrictions: ascii only, base 10 only, positive numbers only, no overflow check
 func atoi(b []byte) int {
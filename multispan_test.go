@@ -1,6 +1,9 @@
 package span
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+)
 import . "github.com/smartystreets/goconvey/convey"
 
 func TestAtoi(t *testing.T) {
@@ -339,3 +342,128 @@ func TestParse(t *testing.T) {
 		})
 	})
 }
+
+func TestParseStrided(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given string representations with steps", t, func() {
+
+		Convey("When the strings are parsed", func() {
+
+			ms1, e1 := Parse("1-10/3")
+			ms2, e2 := Parse("1-7:2")
+			ms3, e3 := Parse("1-6/even,10-13/odd")
+			ms4, e4 := ParseWithin("odd", Span{4, 9})
+
+			Convey("The multispans should hold the stepped members", func() {
+
+				So(e1, ShouldBeNil)
+				So(ms1, ShouldResemble, Multispan{{1, 1}, {4, 4}, {7, 7}, {10, 10}})
+
+				So(e2, ShouldBeNil)
+				So(ms2, ShouldResemble, Multispan{{1, 1}, {3, 3}, {5, 5}, {7, 7}})
+
+				So(e3, ShouldBeNil)
+				So(ms3, ShouldResemble, Multispan{{2, 2}, {4, 4}, {6, 6}, {11, 11}, {13, 13}})
+
+				So(e4, ShouldBeNil)
+				So(ms4, ShouldResemble, Multispan{{5, 5}, {7, 7}, {9, 9}})
+			})
+		})
+
+		Convey("When the strings are malformed", func() {
+
+			_, e1 := Parse("1-10/0")
+			_, e2 := Parse("even")
+			_, e3 := Parse("1-x")
+			_, e4 := Parse("1,,2")
+			_, e5 := Parse("99999999999999999999")
+			_, e6 := Parse("0-100000000/2")
+			_, e7 := ParseWithin("even", Span{0, 100000000})
+			_, e8 := Parse("(-4611686018427387904)-4611686018427387904/2")
+			_, e9 := Parse("(-9223372036854775807)-9223372036854775806/3")
+
+			Convey("Parse should report the error", func() {
+				So(e1, ShouldEqual, ErrInvalidStep)
+				So(e2, ShouldEqual, ErrUnboundedStride)
+				So(e3, ShouldEqual, ErrSyntax)
+				So(e4, ShouldEqual, ErrSyntax)
+				So(e5, ShouldEqual, ErrRange)
+
+				ms, err := Parse("00009223372036854775807")
+				So(err, ShouldBeNil)
+				So(ms, ShouldResemble, Multispan{{PosInf, PosInf}})
+				So(e6, ShouldEqual, ErrStrideTooLong)
+				So(e7, ShouldEqual, ErrStrideTooLong)
+				So(e8, ShouldEqual, ErrStrideTooLong)
+				So(e9, ShouldEqual, ErrStrideTooLong)
+			})
+		})
+	})
+}
+
+func TestMultispanContains(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a normalized multispan", t, func() {
+		ms := Multispan([]Span{{1, 4}, {8, 8}, {10, 20}})
+
+		Convey("Contains() should find points in each span and none in the gaps", func() {
+			So(ms.Contains(1), ShouldBeTrue)
+			So(ms.Contains(4), ShouldBeTrue)
+			So(ms.Contains(8), ShouldBeTrue)
+			So(ms.Contains(15), ShouldBeTrue)
+			So(ms.Contains(0), ShouldBeFalse)
+			So(ms.Contains(5), ShouldBeFalse)
+			So(ms.Contains(21), ShouldBeFalse)
+			So(NewMultiSpan(0).Contains(0), ShouldBeFalse)
+		})
+	})
+}
+
+func TestParseOpenEnded(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given string representations with open ends", t, func() {
+
+		Convey("When the strings are parsed", func() {
+
+			ms1, e1 := Parse("-10,20-")
+			ms2, e2 := Parse("*")
+			ms3, e3 := ParseWithin("-3,8-", Span{1, 10})
+			_, e4 := Parse("5-/2")
+
+			Convey("The open ends should be infinite unless a domain is given", func() {
+				So(e1, ShouldBeNil)
+				So(ms1, ShouldResemble, Multispan{UpTo(10), From(20)})
+				So(ms1.String(), ShouldEqual, "-10,20-")
+
+				So(e2, ShouldBeNil)
+				So(ms2, ShouldResemble, Multispan{Unbounded})
+
+				So(e3, ShouldBeNil)
+				So(ms3, ShouldResemble, Multispan{{1, 3}, {8, 10}})
+
+				So(e4, ShouldEqual, ErrUnboundedStride)
+			})
+		})
+	})
+}
+
+func TestMultispanJSON(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a multispan", t, func() {
+		ms := Multispan{{1, 5}, {8, 8}}
+
+		Convey("It should keep its JSON form as an array of spans", func() {
+			text, err := json.Marshal(ms)
+			So(err, ShouldBeNil)
+			So(string(text), ShouldEqual, `[{"Start":1,"End":5},{"Start":8,"End":8}]`)
+
+			var back Multispan
+			So(json.Unmarshal(text, &back), ShouldBeNil)
+			So(back, ShouldResemble, ms)
+		})
+	})
+}
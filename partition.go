@@ -0,0 +1,114 @@
+package span
+
+import (
+	"errors"
+)
+
+var ErrSplit = errors.New("Split point is outside the span")
+var ErrUnbounded = errors.New("Span is unbounded or too large")
+var ErrInvalidSize = errors.New("Size must be positive")
+
+// SplitAt cuts the span so that n starts the second part. Both parts must
+// be non-empty, so n must be in (Start, End].
+func (s Span) SplitAt(n int) (Span, Span, error) {
+	if n <= s.Start || n > s.End {
+		return Zero, Zero, ErrSplit
+	}
+
+	return Span{Start: s.Start, End: n - 1}, Span{Start: n, End: s.End}, nil
+}
+
+// Bisect cuts the span into two halves, the first taking the extra integer
+// when the length is odd.
+func (s Span) Bisect() (Span, Span, error) {
+	if !s.IsBounded() {
+		return Zero, Zero, ErrUnbounded
+	}
+
+	return s.SplitAt(s.Start + int((uint(s.End)-uint(s.Start))/2) + 1)
+}
+
+// Chunks cuts a normalized multispan into consecutive pieces holding at
+// most n integers each. Only the last piece may hold fewer than n.
+func (ms Multispan) Chunks(n int) ([]Multispan, error) {
+	if n < 1 {
+		return nil, ErrInvalidSize
+	}
+
+	size := ms.Size()
+	if size == PosInf {
+		return nil, ErrUnbounded
+	}
+
+	sizes := make([]int, 0, size/n+1)
+	for ; size > 0; size -= n {
+		sizes = append(sizes, min(n, size))
+	}
+
+	return ms.cut(sizes), nil
+}
+
+// SplitEven cuts a normalized multispan into k consecutive pieces whose
+// sizes differ by at most one. Earlier pieces take the extra integers, and
+// trailing pieces are empty when there are fewer than k integers.
+func (ms Multispan) SplitEven(k int) ([]Multispan, error) {
+	if k < 1 {
+		return nil, ErrInvalidSize
+	}
+
+	size := ms.Size()
+	if size == PosInf {
+		return nil, ErrUnbounded
+	}
+
+	sizes := make([]int, k)
+	for i := range sizes {
+		sizes[i] = size / k
+		if i < size%k {
+			sizes[i]++
+		}
+	}
+
+	return ms.cut(sizes), nil
+}
+
+// cut walks the spans in order, handing out the given number of integers
+// to each piece
+func (ms Multispan) cut(sizes []int) []Multispan {
+
+	parts := make([]Multispan, len(sizes))
+
+	i := 0
+	var rest Span
+	if len(ms) > 0 {
+		rest = ms[0]
+	}
+
+	for p, want := range sizes {
+
+		part := NewMultiSpan(1)
+
+		for want > 0 && i < len(ms) {
+
+			if rest.Len() <= want {
+				part = append(part, rest)
+				want -= rest.Len()
+
+				i++
+				if i < len(ms) {
+					rest = ms[i]
+				}
+				continue
+			}
+
+			head, tail, _ := rest.SplitAt(rest.Start + want)
+			part = append(part, head)
+			rest = tail
+			want = 0
+		}
+
+		parts[p] = part
+	}
+
+	return parts
+}
@@ -0,0 +1,96 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestSplitAt(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a span", t, func() {
+		s := Span{1, 10}
+
+		Convey("SplitAt() should start the second part at the split point", func() {
+			a, b, err := s.SplitAt(4)
+			So(err, ShouldBeNil)
+			So(a, ShouldResemble, Span{1, 3})
+			So(b, ShouldResemble, Span{4, 10})
+
+			a, b, err = s.SplitAt(10)
+			So(err, ShouldBeNil)
+			So(a, ShouldResemble, Span{1, 9})
+			So(b, ShouldResemble, Span{10, 10})
+
+			_, _, err = s.SplitAt(1)
+			So(err, ShouldEqual, ErrSplit)
+			_, _, err = s.SplitAt(11)
+			So(err, ShouldEqual, ErrSplit)
+		})
+
+		Convey("Bisect() should give halves with the extra integer first", func() {
+			a, b, err := s.Bisect()
+			So(err, ShouldBeNil)
+			So(a, ShouldResemble, Span{1, 5})
+			So(b, ShouldResemble, Span{6, 10})
+
+			a, b, err = Span{-3, 1}.Bisect()
+			So(err, ShouldBeNil)
+			So(a, ShouldResemble, Span{-3, -1})
+			So(b, ShouldResemble, Span{0, 1})
+
+			_, _, err = Span{4, 4}.Bisect()
+			So(err, ShouldEqual, ErrSplit)
+			_, _, err = From(4).Bisect()
+			So(err, ShouldEqual, ErrUnbounded)
+		})
+	})
+}
+
+func TestChunks(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a normalized multispan of 12 integers", t, func() {
+		ms := Multispan{{1, 5}, {10, 10}, {20, 25}}
+
+		Convey("Chunks() should fill each piece before starting the next", func() {
+			parts, err := ms.Chunks(4)
+
+			So(err, ShouldBeNil)
+			So(parts, ShouldResemble, []Multispan{
+				{{1, 4}},
+				{{5, 5}, {10, 10}, {20, 21}},
+				{{22, 25}},
+			})
+
+			parts, err = ms.Chunks(5)
+			So(err, ShouldBeNil)
+			So(parts, ShouldHaveLength, 3)
+			So(parts[2], ShouldResemble, Multispan{{24, 25}})
+		})
+
+		Convey("SplitEven() should balance the pieces", func() {
+			parts, err := ms.SplitEven(5)
+
+			So(err, ShouldBeNil)
+			So(parts, ShouldResemble, []Multispan{
+				{{1, 3}},
+				{{4, 5}, {10, 10}},
+				{{20, 21}},
+				{{22, 23}},
+				{{24, 25}},
+			})
+
+			parts, err = Multispan{{1, 2}}.SplitEven(3)
+			So(err, ShouldBeNil)
+			So(parts, ShouldResemble, []Multispan{{{1, 1}}, {{2, 2}}, {}})
+		})
+
+		Convey("Bad sizes and unbounded input should be rejected", func() {
+			_, err := ms.Chunks(0)
+			So(err, ShouldEqual, ErrInvalidSize)
+			_, err = ms.SplitEven(0)
+			So(err, ShouldEqual, ErrInvalidSize)
+			_, err = Multispan{From(1)}.Chunks(10)
+			So(err, ShouldEqual, ErrUnbounded)
+		})
+	})
+}
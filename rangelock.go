@@ -0,0 +1,248 @@
+package span
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var ErrUpgradeDeadlock = errors.New("Upgrade would deadlock with another upgrade")
+var ErrNotHeld = errors.New("Lock is not held")
+
+// RangeLock is a reader/writer lock over ranges of integers, like fcntl
+// byte range locks within one process. Readers may share overlapping
+// ranges; a writer excludes everything overlapping its range.
+//
+// Waiters are served first in, first out: a request waits behind any
+// earlier request it conflicts with, so a stream of readers cannot starve
+// a writer. Requests that conflict with nothing ahead of them go straight
+// through.
+type RangeLock struct {
+	mu      sync.Mutex
+	held    []*lockRequest
+	waiting []*lockRequest
+}
+
+type lockRequest struct {
+	span      Span
+	exclusive bool
+	upgrade   *lockRequest // the shared lock this request upgrades
+	ready     chan struct{}
+}
+
+// RangeHandle is a granted lock. Unlock releases it.
+type RangeHandle struct {
+	rl  *RangeLock
+	req *lockRequest
+}
+
+func NewRangeLock() *RangeLock {
+	return &RangeLock{}
+}
+
+// RLock waits for a shared lock on s, or until ctx is done
+func (rl *RangeLock) RLock(ctx context.Context, s Span) (*RangeHandle, error) {
+	return rl.acquire(ctx, &lockRequest{span: s.Normalize()})
+}
+
+// Lock waits for an exclusive lock on s, or until ctx is done
+func (rl *RangeLock) Lock(ctx context.Context, s Span) (*RangeHandle, error) {
+	return rl.acquire(ctx, &lockRequest{span: s.Normalize(), exclusive: true})
+}
+
+func (rl *RangeLock) acquire(ctx context.Context, req *lockRequest) (*RangeHandle, error) {
+
+	req.ready = make(chan struct{})
+
+	rl.mu.Lock()
+	rl.waiting = append(rl.waiting, req)
+	rl.grant()
+	rl.mu.Unlock()
+
+	if err := rl.wait(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return &RangeHandle{rl: rl, req: req}, nil
+}
+
+// wait blocks until req is granted. If ctx finishes first the request is
+// withdrawn, unless it was granted in the meantime.
+func (rl *RangeLock) wait(ctx context.Context, req *lockRequest) error {
+
+	select {
+	case <-req.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	select {
+	case <-req.ready:
+		return nil
+	default:
+	}
+
+	rl.waiting = remove(rl.waiting, req)
+	rl.grant()
+
+	return ctx.Err()
+}
+
+// grant hands out every waiting request that conflicts with no held lock
+// and no request queued ahead of it. rl.mu must be held.
+func (rl *RangeLock) grant() {
+
+	kept := rl.waiting[:0]
+
+	for _, w := range rl.waiting {
+
+		if conflicts(w, rl.held) || conflicts(w, kept) {
+			kept = append(kept, w)
+			continue
+		}
+
+		if w.upgrade != nil {
+			rl.held = remove(rl.held, w.upgrade)
+		}
+
+		rl.held = append(rl.held, w)
+		close(w.ready)
+	}
+
+	for i := len(kept); i < len(rl.waiting); i++ {
+		rl.waiting[i] = nil
+	}
+	rl.waiting = kept
+}
+
+// conflicts reports whether req clashes with any of others, ignoring the
+// lock it upgrades
+func conflicts(req *lockRequest, others []*lockRequest) bool {
+
+	for _, o := range others {
+
+		if o == req.upgrade {
+			continue
+		}
+
+		if (req.exclusive || o.exclusive) && req.span.Overlaps(o.span) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func holds(reqs []*lockRequest, req *lockRequest) bool {
+
+	for _, r := range reqs {
+		if r == req {
+			return true
+		}
+	}
+
+	return false
+}
+
+func remove(reqs []*lockRequest, req *lockRequest) []*lockRequest {
+
+	for i, r := range reqs {
+		if r == req {
+			return append(reqs[:i], reqs[i+1:]...)
+		}
+	}
+
+	return reqs
+}
+
+func (h *RangeHandle) Span() Span {
+
+	h.rl.mu.Lock()
+	defer h.rl.mu.Unlock()
+
+	return h.req.span
+}
+
+// Exclusive reports whether the handle holds a write lock
+func (h *RangeHandle) Exclusive() bool {
+
+	h.rl.mu.Lock()
+	defer h.rl.mu.Unlock()
+
+	return h.req.exclusive
+}
+
+// Unlock releases the lock. Calling it again does nothing.
+func (h *RangeHandle) Unlock() {
+
+	h.rl.mu.Lock()
+	h.rl.held = remove(h.rl.held, h.req)
+	h.rl.grant()
+	h.rl.mu.Unlock()
+}
+
+// Upgrade turns a shared lock into an exclusive one, waiting for other
+// readers of the range to leave. It goes ahead of queued requests, since
+// they would otherwise wait on this reader forever. If another reader of
+// an overlapping range is already upgrading, the two could never both
+// succeed, so this fails with ErrUpgradeDeadlock. If ctx finishes first
+// the shared lock is kept. Upgrading a released handle fails with
+// ErrNotHeld.
+func (h *RangeHandle) Upgrade(ctx context.Context) error {
+
+	rl := h.rl
+	rl.mu.Lock()
+
+	if !holds(rl.held, h.req) {
+		rl.mu.Unlock()
+		return ErrNotHeld
+	}
+
+	if h.req.exclusive {
+		rl.mu.Unlock()
+		return nil
+	}
+
+	for _, w := range rl.waiting {
+		if w.upgrade != nil && w.span.Overlaps(h.req.span) {
+			rl.mu.Unlock()
+			return ErrUpgradeDeadlock
+		}
+	}
+
+	up := &lockRequest{
+		span:      h.req.span,
+		exclusive: true,
+		upgrade:   h.req,
+		ready:     make(chan struct{}),
+	}
+
+	rl.waiting = append([]*lockRequest{up}, rl.waiting...)
+	rl.grant()
+	rl.mu.Unlock()
+
+	if err := rl.wait(ctx, up); err != nil {
+		return err
+	}
+
+	rl.mu.Lock()
+	h.req = up
+	rl.mu.Unlock()
+
+	return nil
+}
+
+// Downgrade turns an exclusive lock into a shared one, letting waiting
+// readers in. It never blocks, and does nothing to a released handle.
+func (h *RangeHandle) Downgrade() {
+
+	h.rl.mu.Lock()
+	if holds(h.rl.held, h.req) {
+		h.req.exclusive = false
+		h.rl.grant()
+	}
+	h.rl.mu.Unlock()
+}
@@ -0,0 +1,189 @@
+package span
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+import . "github.com/smartystreets/goconvey/convey"
+
+// granted reports whether a lock attempt finishes within a short time.
+// The attempt is cancelled after that, so it doesn't wait on forever.
+func granted(f func(ctx context.Context) error) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- f(ctx) }()
+
+	return <-done == nil
+}
+
+// queued waits until at least n requests are waiting on rl, or a second
+// has passed
+func queued(rl *RangeLock, n int) bool {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		rl.mu.Lock()
+		q := len(rl.waiting)
+		rl.mu.Unlock()
+
+		if q >= n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestRangeLock(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a range lock", t, func() {
+		rl := NewRangeLock()
+		ctx := context.Background()
+
+		Convey("Readers should share overlapping ranges", func() {
+			r1, err := rl.RLock(ctx, Span{0, 10})
+			So(err, ShouldBeNil)
+			r2, err := rl.RLock(ctx, Span{5, 15})
+			So(err, ShouldBeNil)
+			So(r2.Exclusive(), ShouldBeFalse)
+
+			r1.Unlock()
+			r2.Unlock()
+			r2.Unlock()
+		})
+
+		Convey("A writer should wait for overlapping readers but not others", func() {
+			r, _ := rl.RLock(ctx, Span{0, 10})
+
+			So(granted(func(ctx context.Context) error { _, err := rl.Lock(ctx, Span{20, 30}); return err }), ShouldBeTrue)
+
+			done := make(chan *RangeHandle)
+			go func() {
+				w, _ := rl.Lock(ctx, Span{10, 12})
+				done <- w
+			}()
+
+			var w *RangeHandle
+			select {
+			case w = <-done:
+			case <-time.After(20 * time.Millisecond):
+			}
+			So(w, ShouldBeNil)
+
+			r.Unlock()
+			if w == nil {
+				w = <-done
+			}
+			So(w.Span(), ShouldResemble, Span{10, 12})
+			So(w.Exclusive(), ShouldBeTrue)
+		})
+
+		Convey("A waiting writer should hold back later readers", func() {
+			r, _ := rl.RLock(ctx, Span{0, 10})
+
+			wch := make(chan *RangeHandle)
+			go func() {
+				w, _ := rl.Lock(ctx, Span{0, 10})
+				wch <- w
+			}()
+			So(queued(rl, 1), ShouldBeTrue)
+
+			tctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+			_, err := rl.RLock(tctx, Span{5, 6})
+			cancel()
+			So(err, ShouldEqual, context.DeadlineExceeded)
+
+			r.Unlock()
+			w := <-wch
+			w.Unlock()
+		})
+
+		Convey("Cancelling a wait should withdraw the request", func() {
+			w, _ := rl.Lock(ctx, Span{0, 10})
+
+			tctx, cancel := context.WithCancel(ctx)
+			cancel()
+			_, err := rl.Lock(tctx, Span{5, 20})
+			So(err, ShouldEqual, context.Canceled)
+
+			So(granted(func(ctx context.Context) error { _, err := rl.RLock(ctx, Span{15, 20}); return err }), ShouldBeTrue)
+			w.Unlock()
+		})
+
+		Convey("Upgrade() should wait for other readers and jump the queue", func() {
+			r1, _ := rl.RLock(ctx, Span{0, 10})
+			r2, _ := rl.RLock(ctx, Span{5, 15})
+
+			// a writer queued first would wait on r1 forever if the upgrade
+			// lined up behind it
+			wch := make(chan *RangeHandle, 1)
+			go func() {
+				w, _ := rl.Lock(ctx, Span{0, 10})
+				wch <- w
+			}()
+			So(queued(rl, 1), ShouldBeTrue)
+
+			up := make(chan error)
+			go func() { up <- r1.Upgrade(ctx) }()
+			So(queued(rl, 2), ShouldBeTrue)
+
+			So(r2.Upgrade(ctx), ShouldEqual, ErrUpgradeDeadlock)
+
+			r2.Unlock()
+			So(<-up, ShouldBeNil)
+			So(r1.Exclusive(), ShouldBeTrue)
+			So(len(wch), ShouldEqual, 0)
+
+			So(granted(func(ctx context.Context) error { _, err := rl.RLock(ctx, Span{0, 0}); return err }), ShouldBeFalse)
+
+			r1.Unlock()
+			w := <-wch
+			So(w.Exclusive(), ShouldBeTrue)
+			w.Unlock()
+		})
+
+		Convey("Downgrade() should let waiting readers in", func() {
+			w, _ := rl.Lock(ctx, Span{0, 10})
+
+			rch := make(chan error, 1)
+			go func() {
+				_, err := rl.RLock(ctx, Span{0, 0})
+				rch <- err
+			}()
+			So(queued(rl, 1), ShouldBeTrue)
+
+			w.Downgrade()
+			So(<-rch, ShouldBeNil)
+			So(w.Exclusive(), ShouldBeFalse)
+		})
+
+		Convey("A released handle should not be upgraded", func() {
+			r, _ := rl.RLock(ctx, Span{0, 10})
+			r.Unlock()
+
+			So(r.Upgrade(ctx), ShouldEqual, ErrNotHeld)
+			So(r.Exclusive(), ShouldBeFalse)
+
+			r.Downgrade()
+			So(granted(func(ctx context.Context) error { _, err := rl.Lock(ctx, Span{0, 10}); return err }), ShouldBeTrue)
+		})
+
+		Convey("Exclusive() should be safe alongside Downgrade()", func() {
+			w, _ := rl.Lock(ctx, Span{0, 10})
+
+			done := make(chan bool)
+			go func() {
+				for i := 0; i < 100; i++ {
+					w.Exclusive()
+				}
+				done <- true
+			}()
+			w.Downgrade()
+			<-done
+
+			So(w.Exclusive(), ShouldBeFalse)
+		})
+	})
+}
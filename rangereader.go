@@ -0,0 +1,132 @@
+package span
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrNegativeOffset = errors.New("Offset is negative")
+var ErrNotCovered = errors.New("Offset is not in a selected range")
+var ErrWhence = errors.New("Invalid whence")
+
+// RangeReader presents the bytes of selected ranges of a source, one after
+// another, as a single stream. Nothing is copied until it is read.
+type RangeReader struct {
+	r   io.ReaderAt
+	ri  *RankIndex
+	off int64
+}
+
+// NewRangeReader reads the byte ranges of ms from r in order of offset.
+// ms is copied and normalized first, so overlapping ranges are read once.
+// The ranges must be bounded and not below zero.
+func NewRangeReader(r io.ReaderAt, ms Multispan) (*RangeReader, error) {
+
+	ms = append(Multispan{}, ms...).NormalizeWithin(1)
+
+	if len(ms) > 0 && ms[0].Start < 0 {
+		return nil, ErrNegativeOffset
+	}
+
+	ri, err := NewRankIndex(ms)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RangeReader{r: r, ri: ri}, nil
+}
+
+// Size is the length of the stitched stream
+func (rr *RangeReader) Size() int64 {
+	return int64(rr.ri.Size())
+}
+
+func (rr *RangeReader) Read(p []byte) (int, error) {
+
+	n, err := rr.ReadAt(p, rr.off)
+	rr.off += int64(n)
+
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+
+	return n, err
+}
+
+// ReadAt reads from the stitched stream at offset off
+func (rr *RangeReader) ReadAt(p []byte, off int64) (int, error) {
+
+	if off < 0 {
+		return 0, ErrNegativeOffset
+	}
+
+	size := rr.Size()
+	read := 0
+
+	for read < len(p) && off < size {
+
+		i := rr.ri.spanOf(int(off))
+		s := rr.ri.ms[i]
+		src := s.Start + int(off) - rr.ri.cum[i]
+
+		// read no further than the end of this span
+		want := min(len(p)-read, s.End-src+1)
+
+		n, err := rr.r.ReadAt(p[read:read+want], int64(src))
+		read += n
+		off += int64(n)
+
+		if err == io.EOF && n < want {
+			return read, io.ErrUnexpectedEOF
+		}
+		if err != nil && err != io.EOF {
+			return read, err
+		}
+	}
+
+	if read < len(p) {
+		return read, io.EOF
+	}
+
+	return read, nil
+}
+
+func (rr *RangeReader) Seek(offset int64, whence int) (int64, error) {
+
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += rr.off
+	case io.SeekEnd:
+		offset += rr.Size()
+	default:
+		return rr.off, ErrWhence
+	}
+
+	if offset < 0 {
+		return rr.off, ErrNegativeOffset
+	}
+
+	rr.off = offset
+
+	return offset, nil
+}
+
+// SourceOffset maps an offset in the stitched stream to the source
+func (rr *RangeReader) SourceOffset(off int64) (int64, error) {
+
+	src, err := rr.ri.Select(int(off))
+
+	return int64(src), err
+}
+
+// StreamOffset maps a source offset to the stitched stream, failing if no
+// selected range covers it
+func (rr *RangeReader) StreamOffset(src int64) (int64, error) {
+
+	if !rr.ri.ms.Contains(int(src)) {
+		return 0, ErrNotCovered
+	}
+
+	return int64(rr.ri.Rank(int(src))), nil
+}
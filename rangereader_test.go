@@ -0,0 +1,108 @@
+package span
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestRangeReader(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a source and selected ranges", t, func() {
+		src := strings.NewReader("0123456789abcdefghij")
+		rr, err := NewRangeReader(src, Multispan{{2, 4}, {10, 11}, {18, 19}})
+		So(err, ShouldBeNil)
+
+		Convey("Reading should stitch the ranges together", func() {
+			So(rr.Size(), ShouldEqual, 7)
+
+			all, err := ioutil.ReadAll(rr)
+			So(err, ShouldBeNil)
+			So(string(all), ShouldEqual, "234abij")
+		})
+
+		Convey("Small reads should stop at range ends", func() {
+			p := make([]byte, 2)
+
+			n, _ := rr.Read(p)
+			So(string(p[:n]), ShouldEqual, "23")
+			n, _ = rr.Read(p)
+			So(string(p[:n]), ShouldEqual, "4a")
+		})
+
+		Convey("Seeking should move within the stitched stream", func() {
+			pos, err := rr.Seek(-3, io.SeekEnd)
+			So(err, ShouldBeNil)
+			So(pos, ShouldEqual, 4)
+
+			rest, _ := ioutil.ReadAll(rr)
+			So(string(rest), ShouldEqual, "bij")
+
+			_, err = rr.Seek(-1, io.SeekStart)
+			So(err, ShouldEqual, ErrNegativeOffset)
+
+			pos, err = rr.Seek(1, 7)
+			So(err, ShouldEqual, ErrWhence)
+			So(pos, ShouldEqual, 7)
+
+			p := make([]byte, 4)
+			n, err := rr.ReadAt(p, 5)
+			So(n, ShouldEqual, 2)
+			So(err, ShouldEqual, io.EOF)
+		})
+
+		Convey("Offsets should map both ways", func() {
+			s, err := rr.SourceOffset(4)
+			So(err, ShouldBeNil)
+			So(s, ShouldEqual, 11)
+
+			o, err := rr.StreamOffset(18)
+			So(err, ShouldBeNil)
+			So(o, ShouldEqual, 5)
+
+			_, err = rr.StreamOffset(5)
+			So(err, ShouldEqual, ErrNotCovered)
+			_, err = rr.SourceOffset(7)
+			So(err, ShouldEqual, ErrNotFound)
+		})
+	})
+
+	Convey("Given ranges past the end of the source", t, func() {
+		rr, _ := NewRangeReader(strings.NewReader("0123"), Multispan{{2, 9}})
+
+		Convey("Reading should report the truncation", func() {
+			_, err := ioutil.ReadAll(rr)
+			So(err, ShouldEqual, io.ErrUnexpectedEOF)
+		})
+	})
+
+	Convey("Given unsorted and overlapping ranges", t, func() {
+		src := strings.NewReader("0123456789")
+		ms := Multispan{{7, 8}, {3, 6}, {1, 5}}
+
+		rr, err := NewRangeReader(src, ms)
+		So(err, ShouldBeNil)
+
+		Convey("Each byte should be read once, in order of offset", func() {
+			all, err := ioutil.ReadAll(rr)
+			So(err, ShouldBeNil)
+			So(string(all), ShouldEqual, "12345678")
+			So(ms, ShouldResemble, Multispan{{7, 8}, {3, 6}, {1, 5}})
+		})
+	})
+
+	Convey("Given bad ranges", t, func() {
+		_, e1 := NewRangeReader(strings.NewReader(""), Multispan{{-1, 3}})
+		_, e2 := NewRangeReader(strings.NewReader(""), Multispan{From(3)})
+		_, e3 := NewRangeReader(strings.NewReader(""), Multispan{{5, 6}, {-3, 1}})
+
+		Convey("NewRangeReader() should reject them", func() {
+			So(e1, ShouldEqual, ErrNegativeOffset)
+			So(e2, ShouldEqual, ErrUnbounded)
+			So(e3, ShouldEqual, ErrNegativeOffset)
+		})
+	})
+}
@@ -0,0 +1,114 @@
+package span
+
+import (
+	"sort"
+)
+
+// Order statistics. Select(k) is the k-th covered integer counting from
+// zero and Rank(n) is how many covered integers are less than n, so
+// Rank(Select(k)) == k. The multispan must be normalized.
+
+// Select walks the spans to find the k-th covered integer. Use a RankIndex
+// for repeated queries.
+func (ms Multispan) Select(k int) (int, error) {
+
+	if k < 0 {
+		return 0, ErrNotFound
+	}
+
+	for _, s := range ms {
+
+		if s.Start == NegInf {
+			return 0, ErrUnbounded
+		}
+
+		if s.End == PosInf || k < s.Len() {
+			return s.Start + k, nil
+		}
+
+		k -= s.Len()
+	}
+
+	return 0, ErrNotFound
+}
+
+// Rank walks the spans to count the covered integers less than n. It is
+// PosInf if there are infinitely many.
+func (ms Multispan) Rank(n int) int {
+
+	r := 0
+
+	for _, s := range ms {
+
+		if s.Start >= n {
+			break
+		}
+
+		if s.Start == NegInf {
+			return PosInf
+		}
+
+		r += min(s.End, n-1) - s.Start + 1
+	}
+
+	return r
+}
+
+// RankIndex keeps running totals of span lengths so Select and Rank are
+// binary searches.
+type RankIndex struct {
+	ms  Multispan
+	cum []int // cum[i] is the number of integers in ms[:i]
+}
+
+// NewRankIndex indexes a normalized, bounded multispan holding fewer than
+// PosInf integers
+func NewRankIndex(ms Multispan) (*RankIndex, error) {
+
+	cum := make([]int, len(ms)+1)
+
+	for i, s := range ms {
+		if !s.IsBounded() || s.Len() > PosInf-cum[i] {
+			return nil, ErrUnbounded
+		}
+
+		cum[i+1] = cum[i] + s.Len()
+	}
+
+	return &RankIndex{ms: ms, cum: cum}, nil
+}
+
+// Size is the number of integers covered
+func (ri *RankIndex) Size() int {
+	return ri.cum[len(ri.ms)]
+}
+
+func (ri *RankIndex) Select(k int) (int, error) {
+
+	if k < 0 || k >= ri.Size() {
+		return 0, ErrNotFound
+	}
+
+	i := ri.spanOf(k)
+
+	return ri.ms[i].Start + k - ri.cum[i], nil
+}
+
+// spanOf is the index of the span holding the k-th integer, which must
+// be in range
+func (ri *RankIndex) spanOf(k int) int {
+	return sort.Search(len(ri.ms), func(i int) bool {
+		return ri.cum[i+1] > k
+	})
+}
+
+func (ri *RankIndex) Rank(n int) int {
+
+	i := ri.ms.search(n)
+
+	if i == len(ri.ms) || ri.ms[i].Start >= n {
+		return ri.cum[i]
+	}
+
+	return ri.cum[i] + n - ri.ms[i].Start
+}
@@ -0,0 +1,66 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestRankSelect(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a sparse normalized multispan and its index", t, func() {
+		ms := Multispan{{3, 5}, {10, 10}, {20, 23}}
+		ri, err := NewRankIndex(ms)
+
+		So(err, ShouldBeNil)
+		So(ri.Size(), ShouldEqual, 8)
+
+		Convey("Select() should find the k-th covered integer both ways", func() {
+			want := []int{3, 4, 5, 10, 20, 21, 22, 23}
+
+			for k, w := range want {
+				n, err := ms.Select(k)
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, w)
+
+				n, err = ri.Select(k)
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, w)
+			}
+
+			_, err := ms.Select(8)
+			So(err, ShouldEqual, ErrNotFound)
+			_, err = ri.Select(8)
+			So(err, ShouldEqual, ErrNotFound)
+			_, err = ri.Select(-1)
+			So(err, ShouldEqual, ErrNotFound)
+		})
+
+		Convey("Rank() should count covered integers below n both ways", func() {
+			want := map[int]int{0: 0, 3: 0, 4: 1, 6: 3, 10: 3, 11: 4, 21: 5, 23: 7, 24: 8, 100: 8}
+
+			for n, w := range want {
+				So(ms.Rank(n), ShouldEqual, w)
+				So(ri.Rank(n), ShouldEqual, w)
+			}
+		})
+	})
+
+	Convey("Given unbounded multispans", t, func() {
+		after := Multispan{{1, 2}, From(10)}
+		before := Multispan{UpTo(0)}
+
+		Convey("Select() and Rank() should cope where they can", func() {
+			n, err := after.Select(5)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 13)
+
+			So(after.Rank(12), ShouldEqual, 4)
+			So(before.Rank(-5), ShouldEqual, PosInf)
+
+			_, err = before.Select(0)
+			So(err, ShouldEqual, ErrUnbounded)
+
+			_, err = NewRankIndex(after)
+			So(err, ShouldEqual, ErrUnbounded)
+		})
+	})
+}
@@ -0,0 +1,152 @@
+package span
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// Interval scheduling over plain slices of spans. Results refer to spans
+// by their index in the input, which is never reordered.
+
+// MaxNonOverlapping picks as many pairwise non-overlapping spans as
+// possible, greedily by earliest end. The indexes are returned in order of
+// position.
+func MaxNonOverlapping(spans []Span) []int {
+
+	order := byEnd(spans)
+	chosen := make([]int, 0, len(spans))
+
+	for _, i := range order {
+		if len(chosen) == 0 || !spans[chosen[len(chosen)-1]].Overlaps(spans[i]) {
+			chosen = append(chosen, i)
+		}
+	}
+
+	return chosen
+}
+
+// MaxWeightNonOverlapping picks the pairwise non-overlapping spans with
+// the largest total weight, returning their indexes in order of position
+// and the total. Spans with a weight of zero or less are never chosen.
+func MaxWeightNonOverlapping(spans []Span, weight func(i int) int) ([]int, int) {
+
+	order := byEnd(spans)
+	n := len(order)
+
+	// prev[j] is how many of the first j spans by end finish before
+	// span j starts, so best[prev[j]] is the best schedule it can follow
+	prev := make([]int, n)
+	for j, i := range order {
+		prev[j] = sort.Search(j, func(k int) bool {
+			return spans[order[k]].End >= spans[i].Start
+		})
+	}
+
+	// best[j] is the best total using only the first j spans by end
+	best := make([]int, n+1)
+	for j, i := range order {
+		best[j+1] = max(best[j], best[prev[j]]+weight(i))
+	}
+
+	chosen := make([]int, 0)
+	for j := n; j > 0; {
+		i := order[j-1]
+		if best[j] != best[j-1] {
+			chosen = append(chosen, i)
+			j = prev[j-1]
+		} else {
+			j--
+		}
+	}
+
+	// walked back from the end, so put them in order
+	for l, r := 0, len(chosen)-1; l < r; l, r = l+1, r-1 {
+		chosen[l], chosen[r] = chosen[r], chosen[l]
+	}
+
+	return chosen, best[n]
+}
+
+// ColorIntervals assigns each span a track, numbered from zero, so that no
+// two overlapping spans share a track, using as few tracks as possible. It
+// returns the track of each span and the number of tracks.
+func ColorIntervals(spans []Span) ([]int, int) {
+
+	order := make([]int, len(spans))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return spans[order[a]].Start < spans[order[b]].Start
+	})
+
+	tracks := make([]int, len(spans))
+	active := &activeSpans{spans: spans}
+	free := &intHeap{}
+	used := 0
+
+	for _, i := range order {
+
+		// release tracks of spans that finished before this one
+		for active.Len() > 0 && !spans[active.idx[0]].Overlaps(spans[i]) {
+			heap.Push(free, tracks[heap.Pop(active).(int)])
+		}
+
+		if free.Len() > 0 {
+			tracks[i] = heap.Pop(free).(int)
+		} else {
+			tracks[i] = used
+			used++
+		}
+
+		heap.Push(active, i)
+	}
+
+	return tracks, used
+}
+
+// byEnd returns span indexes ordered by end
+func byEnd(spans []Span) []int {
+
+	order := make([]int, len(spans))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return spans[order[a]].End < spans[order[b]].End
+	})
+
+	return order
+}
+
+// activeSpans is a min-heap of span indexes by end
+type activeSpans struct {
+	spans []Span
+	idx   []int
+}
+
+func (h *activeSpans) Len() int           { return len(h.idx) }
+func (h *activeSpans) Less(i, j int) bool { return h.spans[h.idx[i]].End < h.spans[h.idx[j]].End }
+func (h *activeSpans) Swap(i, j int)      { h.idx[i], h.idx[j] = h.idx[j], h.idx[i] }
+func (h *activeSpans) Push(x interface{}) { h.idx = append(h.idx, x.(int)) }
+func (h *activeSpans) Pop() interface{} {
+	x := h.idx[len(h.idx)-1]
+	h.idx = h.idx[:len(h.idx)-1]
+	return x
+}
+
+// intHeap is a min-heap of ints
+type intHeap []int
+
+func (h intHeap) Len() int            { return len(h) }
+func (h intHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x interface{}) { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,67 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestMaxNonOverlapping(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given overlapping jobs", t, func() {
+		spans := []Span{{1, 4}, {3, 5}, {0, 6}, {5, 7}, {3, 9}, {5, 9}, {6, 10}, {8, 11}, {8, 12}, {2, 14}, {12, 16}}
+
+		Convey("MaxNonOverlapping() should pick the most jobs by earliest end", func() {
+			So(MaxNonOverlapping(spans), ShouldResemble, []int{0, 3, 7, 10})
+		})
+
+		Convey("Spans sharing an endpoint should count as overlapping", func() {
+			So(MaxNonOverlapping([]Span{{1, 5}, {5, 8}, {9, 9}}), ShouldResemble, []int{0, 2})
+			So(MaxNonOverlapping(nil), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestMaxWeightNonOverlapping(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given weighted jobs", t, func() {
+		spans := []Span{{1, 3}, {2, 5}, {4, 6}, {6, 7}, {5, 8}, {7, 9}}
+		weights := []int{5, 6, 5, 4, 11, 2}
+		weight := func(i int) int { return weights[i] }
+
+		Convey("MaxWeightNonOverlapping() should find the heaviest schedule", func() {
+			chosen, total := MaxWeightNonOverlapping(spans, weight)
+			So(chosen, ShouldResemble, []int{0, 4})
+			So(total, ShouldEqual, 16)
+		})
+
+		Convey("With equal weights it should pick as many as the greedy choice", func() {
+			chosen, total := MaxWeightNonOverlapping(spans, func(int) int { return 1 })
+			So(total, ShouldEqual, len(MaxNonOverlapping(spans)))
+			So(chosen, ShouldHaveLength, total)
+		})
+	})
+}
+
+func TestColorIntervals(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given overlapping bookings", t, func() {
+		spans := []Span{{1, 4}, {2, 6}, {5, 8}, {3, 3}, {7, 10}, {9, 12}, {4, 4}}
+
+		Convey("ColorIntervals() should use as few tracks as the deepest overlap", func() {
+			tracks, n := ColorIntervals(spans)
+			depth, _ := Multispan(spans).MaxDepth()
+
+			So(n, ShouldEqual, depth)
+			So(tracks, ShouldResemble, []int{0, 1, 0, 2, 1, 0, 2})
+
+			for i := range spans {
+				for j := i + 1; j < len(spans); j++ {
+					if spans[i].Overlaps(spans[j]) {
+						So(tracks[i], ShouldNotEqual, tracks[j])
+					}
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,109 @@
+package span
+
+import (
+	"errors"
+	"sort"
+)
+
+// Successor and predecessor queries. The multispan must be normalized.
+
+var ErrNotFound = errors.New("No such integer")
+var ErrEmpty = errors.New("Multispan is empty")
+
+// NextIn is the first covered integer not less than n
+func (ms Multispan) NextIn(n int) (int, error) {
+
+	i := ms.search(n)
+	if i == len(ms) {
+		return 0, ErrNotFound
+	}
+
+	return max(n, ms[i].Start), nil
+}
+
+// PrevIn is the last covered integer not greater than n
+func (ms Multispan) PrevIn(n int) (int, error) {
+
+	i := sort.Search(len(ms), func(i int) bool {
+		return ms[i].Start > n
+	}) - 1
+
+	if i < 0 {
+		return 0, ErrNotFound
+	}
+
+	return min(n, ms[i].End), nil
+}
+
+// NextGap is the first uncovered integer not less than n
+func (ms Multispan) NextGap(n int) (int, error) {
+
+	i := ms.search(n)
+	if i == len(ms) || ms[i].Start > n {
+		return n, nil
+	}
+
+	// walk through spans that touch end to start
+	end := ms[i].End
+	for ; end != PosInf; i++ {
+		if i+1 == len(ms) || ms[i+1].Start > end+1 {
+			return end + 1, nil
+		}
+		end = max(end, ms[i+1].End)
+	}
+
+	return 0, ErrNotFound
+}
+
+// PrevGap is the last uncovered integer not greater than n
+func (ms Multispan) PrevGap(n int) (int, error) {
+
+	i := sort.Search(len(ms), func(i int) bool {
+		return ms[i].Start > n
+	}) - 1
+
+	if i < 0 || ms[i].End < n {
+		return n, nil
+	}
+
+	start := ms[i].Start
+	for ; start != NegInf; i-- {
+		if i == 0 || ms[i-1].End < start-1 {
+			return start - 1, nil
+		}
+		start = min(start, ms[i-1].Start)
+	}
+
+	return 0, ErrNotFound
+}
+
+// Nearest is the span closest to n, which is the span containing n if
+// there is one. Ties go to the lower span.
+func (ms Multispan) Nearest(n int) (Span, error) {
+
+	if len(ms) == 0 {
+		return Zero, ErrEmpty
+	}
+
+	i := ms.search(n)
+
+	switch {
+	case i == len(ms):
+		return ms[i-1], nil
+	case i == 0 || ms[i].Start <= n:
+		return ms[i], nil
+	}
+
+	if ms[i].Start-n < n-ms[i-1].End {
+		return ms[i], nil
+	}
+
+	return ms[i-1], nil
+}
+
+// search finds the first span ending at or after n
+func (ms Multispan) search(n int) int {
+	return sort.Search(len(ms), func(i int) bool {
+		return ms[i].End >= n
+	})
+}
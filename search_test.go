@@ -0,0 +1,81 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestNextPrevIn(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a normalized multispan", t, func() {
+		ms := Multispan{{1, 4}, {5, 8}, {12, 12}, {20, 30}}
+
+		Convey("NextIn() and PrevIn() should find covered integers", func() {
+			n, err := ms.NextIn(3)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 3)
+
+			n, _ = ms.NextIn(9)
+			So(n, ShouldEqual, 12)
+
+			n, _ = ms.NextIn(-100)
+			So(n, ShouldEqual, 1)
+
+			_, err = ms.NextIn(31)
+			So(err, ShouldEqual, ErrNotFound)
+
+			n, _ = ms.PrevIn(15)
+			So(n, ShouldEqual, 12)
+
+			n, _ = ms.PrevIn(30)
+			So(n, ShouldEqual, 30)
+
+			_, err = ms.PrevIn(0)
+			So(err, ShouldEqual, ErrNotFound)
+		})
+
+		Convey("NextGap() and PrevGap() should skip touching spans", func() {
+			n, err := ms.NextGap(2)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 9)
+
+			n, _ = ms.NextGap(10)
+			So(n, ShouldEqual, 10)
+
+			n, _ = ms.NextGap(12)
+			So(n, ShouldEqual, 13)
+
+			n, _ = ms.PrevGap(7)
+			So(n, ShouldEqual, 0)
+
+			n, _ = ms.PrevGap(25)
+			So(n, ShouldEqual, 19)
+
+			_, err = Multispan{From(5)}.NextGap(7)
+			So(err, ShouldEqual, ErrNotFound)
+
+			_, err = Multispan{UpTo(5)}.PrevGap(3)
+			So(err, ShouldEqual, ErrNotFound)
+		})
+
+		Convey("Nearest() should find the closest span", func() {
+			s, err := ms.Nearest(6)
+			So(err, ShouldBeNil)
+			So(s, ShouldResemble, Span{5, 8})
+
+			s, _ = ms.Nearest(10)
+			So(s, ShouldResemble, Span{5, 8})
+
+			s, _ = ms.Nearest(17)
+			So(s, ShouldResemble, Span{20, 30})
+
+			s, _ = ms.Nearest(16)
+			So(s, ShouldResemble, Span{12, 12})
+
+			s, _ = ms.Nearest(99)
+			So(s, ShouldResemble, Span{20, 30})
+
+			_, err = NewMultiSpan(0).Nearest(1)
+			So(err, ShouldEqual, ErrEmpty)
+		})
+	})
+}
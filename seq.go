@@ -0,0 +1,125 @@
+package span
+
+// recentMarks is how many marked ranges SeqTracker remembers for ordering
+// SACK blocks
+const recentMarks = 16
+
+// SeqTracker records received sequence numbers from a base upwards,
+// reporting the contiguous watermark, what is missing, and SACK blocks.
+type SeqTracker struct {
+	base     int
+	received Multispan // normalized, touching spans merged
+	recent   []Span    // most recently marked first
+}
+
+// NewSeqTracker tracks sequence numbers from base upwards
+func NewSeqTracker(base int) *SeqTracker {
+	return &SeqTracker{base: base, received: NewMultiSpan(0)}
+}
+
+func (st *SeqTracker) Mark(n int) {
+	st.MarkRange(Span{n, n})
+}
+
+// MarkRange records every number in s as received. Numbers below the base
+// are ignored.
+func (st *SeqTracker) MarkRange(s Span) {
+
+	s, err := s.Normalize().Clamp(From(st.base))
+	if err != nil {
+		return
+	}
+
+	st.received = st.received.Union(Multispan{s}).NormalizeWithin(1)
+
+	if len(st.recent) == recentMarks {
+		st.recent = st.recent[:recentMarks-1]
+	}
+	st.recent = append([]Span{s}, st.recent...)
+}
+
+// markAll records every span in ms in one pass, without counting them as
+// recent arrivals, as when restoring saved state
+func (st *SeqTracker) markAll(ms Multispan) {
+
+	clamped := NewMultiSpan(len(ms))
+
+	for _, s := range ms {
+		if c, err := s.Normalize().Clamp(From(st.base)); err == nil {
+			clamped = append(clamped, c)
+		}
+	}
+
+	st.received = st.received.Union(clamped).NormalizeWithin(1)
+}
+
+// Has reports whether n has been received
+func (st *SeqTracker) Has(n int) bool {
+	return st.received.Contains(n)
+}
+
+// Watermark is the highest n such that everything from the base up to n
+// has been received, or base-1 if the base itself is still missing
+func (st *SeqTracker) Watermark() int {
+
+	if len(st.received) == 0 || st.received[0].Start != st.base {
+		return st.base - 1
+	}
+
+	return st.received[0].End
+}
+
+// Received is the normalized multispan of everything received
+func (st *SeqTracker) Received() Multispan {
+	return append(Multispan{}, st.received...)
+}
+
+// Missing lists the numbers from the base up to and including upTo that
+// have not been received
+func (st *SeqTracker) Missing(upTo int) Multispan {
+
+	if upTo < st.base {
+		return NewMultiSpan(0)
+	}
+
+	return Multispan{{st.base, upTo}}.Subtract(st.received)
+}
+
+// SACKBlocks returns up to limit received blocks above the watermark, as a
+// TCP receiver reports them: the block holding the most recent arrival
+// first, then blocks of other recent arrivals, then the rest from the
+// highest down.
+func (st *SeqTracker) SACKBlocks(limit int) []Span {
+
+	if limit <= 0 {
+		return nil
+	}
+
+	above := st.received
+	if len(above) > 0 && above[0].Start == st.base {
+		above = above[1:]
+	}
+
+	blocks := make([]Span, 0, limit)
+	seen := make(map[int]bool)
+
+	add := func(i int) {
+		if len(blocks) < limit && !seen[i] {
+			seen[i] = true
+			blocks = append(blocks, above[i])
+		}
+	}
+
+	for _, r := range st.recent {
+		i := above.search(r.Start)
+		if i < len(above) && above[i].Contains(r.Start) {
+			add(i)
+		}
+	}
+
+	for i := len(above) - 1; i >= 0; i-- {
+		add(i)
+	}
+
+	return blocks
+}
@@ -0,0 +1,54 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestSeqTracker(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a tracker starting at 100", t, func() {
+		st := NewSeqTracker(100)
+
+		Convey("Before anything arrives the watermark should be below the base", func() {
+			So(st.Watermark(), ShouldEqual, 99)
+			So(st.Missing(102), ShouldResemble, Multispan{{100, 102}})
+			So(st.SACKBlocks(3), ShouldBeEmpty)
+		})
+
+		Convey("When packets arrive out of order", func() {
+			st.Mark(100)
+			st.Mark(101)
+			st.MarkRange(Span{105, 107})
+			st.Mark(103)
+			st.Mark(110)
+			st.Mark(90)
+
+			Convey("The watermark should stop at the first hole", func() {
+				So(st.Watermark(), ShouldEqual, 101)
+				So(st.Has(106), ShouldBeTrue)
+				So(st.Has(90), ShouldBeFalse)
+			})
+
+			Convey("Missing() should list the holes", func() {
+				So(st.Missing(112), ShouldResemble, Multispan{{102, 102}, {104, 104}, {108, 109}, {111, 112}})
+				So(st.Missing(50), ShouldBeEmpty)
+			})
+
+			Convey("SACKBlocks() should lead with the most recent arrivals", func() {
+				So(st.SACKBlocks(3), ShouldResemble, []Span{{110, 110}, {103, 103}, {105, 107}})
+				So(st.SACKBlocks(1), ShouldResemble, []Span{{110, 110}})
+				So(st.SACKBlocks(0), ShouldBeNil)
+				So(st.SACKBlocks(-1), ShouldBeNil)
+			})
+
+			Convey("Filling a hole should merge blocks and raise the watermark", func() {
+				st.Mark(102)
+				st.Mark(104)
+
+				So(st.Watermark(), ShouldEqual, 107)
+				So(st.Received(), ShouldResemble, Multispan{{100, 107}, {110, 110}})
+				So(st.SACKBlocks(3), ShouldResemble, []Span{{110, 110}})
+			})
+		})
+	})
+}
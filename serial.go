@@ -0,0 +1,165 @@
+package span
+
+import (
+	"errors"
+	"strconv"
+)
+
+var ErrSerialBits = errors.New("Serial number width must be 1 to 63 bits")
+var ErrSerialRange = errors.New("Serial span must be shorter than half the number space")
+
+// SerialSpace is the number space of an n bit wrapping counter, compared
+// with RFC 1982 serial number arithmetic
+type SerialSpace struct {
+	bits uint
+	mask uint64
+}
+
+func NewSerialSpace(bits uint) (SerialSpace, error) {
+	if bits < 1 || bits > 63 {
+		return SerialSpace{}, ErrSerialBits
+	}
+
+	return SerialSpace{bits: bits, mask: 1<<bits - 1}, nil
+}
+
+func (sp SerialSpace) Bits() uint {
+	return sp.bits
+}
+
+// half is 2^(bits-1), the distance at which comparison is undefined
+func (sp SerialSpace) half() uint64 {
+	return 1 << (sp.bits - 1)
+}
+
+// Add is a + n, wrapping. RFC 1982 only defines n below half the space.
+func (sp SerialSpace) Add(a, n uint64) uint64 {
+	return (a + n) & sp.mask
+}
+
+// Distance is how far forward b is from a, wrapping
+func (sp SerialSpace) Distance(a, b uint64) uint64 {
+	return (b - a) & sp.mask
+}
+
+// Less reports whether a comes before b. Values exactly half the space
+// apart are unordered, so neither is less than the other.
+func (sp SerialSpace) Less(a, b uint64) bool {
+	a, b = a&sp.mask, b&sp.mask
+	d := sp.Distance(a, b)
+
+	return d != 0 && d < sp.half()
+}
+
+// Span returns the serial span running forward from start to end, which
+// may wrap past zero. It must hold fewer than half the numbers in the
+// space so that its ends stay ordered.
+func (sp SerialSpace) Span(start, end uint64) (SerialSpan, error) {
+	start, end = start&sp.mask, end&sp.mask
+
+	if sp.Distance(start, end) >= sp.half() {
+		return SerialSpan{}, ErrSerialRange
+	}
+
+	return SerialSpan{Start: start, End: end, space: sp}, nil
+}
+
+// SerialSpan is a closed span of serial numbers, running forward from
+// Start to End and wrapping past zero when End < Start. Spans from
+// different spaces must not be mixed.
+type SerialSpan struct {
+	Start uint64
+	End   uint64
+	space SerialSpace
+}
+
+func (s SerialSpan) Space() SerialSpace {
+	return s.space
+}
+
+// Len is the number of serial numbers in the span
+func (s SerialSpan) Len() uint64 {
+	return s.space.Distance(s.Start, s.End) + 1
+}
+
+func (s SerialSpan) Wraps() bool {
+	return s.End < s.Start
+}
+
+func (s SerialSpan) Contains(n uint64) bool {
+	return s.space.Distance(s.Start, n&s.space.mask) <= s.space.Distance(s.Start, s.End)
+}
+
+func (s SerialSpan) Overlaps(t SerialSpan) bool {
+	return s.Contains(t.Start) || t.Contains(s.Start)
+}
+
+func (s SerialSpan) Overlap(t SerialSpan) (SerialSpan, error) {
+	if !s.Overlaps(t) {
+		return SerialSpan{}, ErrNoOverlap
+	}
+
+	// start from whichever start lies inside the other span, and stop at
+	// whichever end comes first
+	start := s.Start
+	if s.Contains(t.Start) {
+		start = t.Start
+	}
+
+	end := s.End
+	if s.space.Distance(start, t.End) < s.space.Distance(start, s.End) {
+		end = t.End
+	}
+
+	return SerialSpan{Start: start, End: end, space: s.space}, nil
+}
+
+// Combine is the span covering both. It fails with ErrSerialRange if that
+// would hold half the space or more.
+func (s SerialSpan) Combine(t SerialSpan) (SerialSpan, error) {
+	if !s.Overlaps(t) {
+		return SerialSpan{}, ErrNoOverlap
+	}
+
+	start := t.Start
+	if s.Contains(t.Start) {
+		start = s.Start
+	}
+
+	end := s.End
+	if s.space.Distance(start, t.End) > s.space.Distance(start, s.End) {
+		end = t.End
+	}
+
+	return s.space.Span(start, end)
+}
+
+// Gap is the span between the two, sharing their ends as Span.Gap does.
+// Of the two ways round, the shorter one is the gap.
+func (s SerialSpan) Gap(t SerialSpan) (SerialSpan, error) {
+	if s.Overlaps(t) {
+		return SerialSpan{}, ErrNoGap
+	}
+
+	if s.space.Distance(s.End, t.Start) <= s.space.Distance(t.End, s.Start) {
+		return SerialSpan{Start: s.End, End: t.Start, space: s.space}, nil
+	}
+
+	return SerialSpan{Start: t.End, End: s.Start, space: s.space}, nil
+}
+
+// Spans converts the serial span to plain spans: one, or two if it wraps
+func (s SerialSpan) Spans() Multispan {
+	if s.Wraps() {
+		return Multispan{
+			{Start: 0, End: int(s.End)},
+			{Start: int(s.Start), End: int(s.space.mask)},
+		}
+	}
+
+	return Multispan{{Start: int(s.Start), End: int(s.End)}}
+}
+
+func (s SerialSpan) String() string {
+	return strconv.FormatUint(s.Start, 10) + "-" + strconv.FormatUint(s.End, 10)
+}
@@ -0,0 +1,96 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestSerialSpace(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given 32 and 16 bit serial spaces", t, func() {
+		sp32, err := NewSerialSpace(32)
+		So(err, ShouldBeNil)
+		sp16, _ := NewSerialSpace(16)
+
+		Convey("Less() should follow RFC 1982", func() {
+			So(sp32.Less(0xFFFFFFF0, 0x10), ShouldBeTrue)
+			So(sp32.Less(0x10, 0xFFFFFFF0), ShouldBeFalse)
+			So(sp32.Less(5, 5), ShouldBeFalse)
+			So(sp16.Less(0, 0x8000), ShouldBeFalse)
+			So(sp16.Less(0x8000, 0), ShouldBeFalse)
+			So(sp16.Less(0, 0x7FFF), ShouldBeTrue)
+			So(sp16.Add(0xFFFF, 2), ShouldEqual, uint64(1))
+		})
+
+		Convey("Bad widths and oversized spans should be rejected", func() {
+			_, err := NewSerialSpace(0)
+			So(err, ShouldEqual, ErrSerialBits)
+			_, err = NewSerialSpace(64)
+			So(err, ShouldEqual, ErrSerialBits)
+			_, err = sp16.Span(0, 0x8000)
+			So(err, ShouldEqual, ErrSerialRange)
+		})
+	})
+}
+
+func TestSerialSpan(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a span wrapping past zero", t, func() {
+		sp, _ := NewSerialSpace(32)
+		s, err := sp.Span(0xFFFFFFF0, 0x10)
+		So(err, ShouldBeNil)
+
+		Convey("It should keep its ends and contain both sides of zero", func() {
+			So(s.Start, ShouldEqual, uint64(0xFFFFFFF0))
+			So(s.Wraps(), ShouldBeTrue)
+			So(s.Len(), ShouldEqual, uint64(33))
+			So(s.Contains(0xFFFFFFFF), ShouldBeTrue)
+			So(s.Contains(0), ShouldBeTrue)
+			So(s.Contains(0x11), ShouldBeFalse)
+			So(s.Contains(0xFFFFFFEF), ShouldBeFalse)
+		})
+
+		Convey("Overlap() and Combine() should work across zero", func() {
+			t1, _ := sp.Span(0x8, 0x20)
+			t2, _ := sp.Span(0xFFFFFF00, 0xFFFFFFF4)
+
+			o, err := s.Overlap(t1)
+			So(err, ShouldBeNil)
+			So(o.String(), ShouldEqual, "8-16")
+
+			o, _ = t2.Overlap(s)
+			So(o.String(), ShouldEqual, "4294967280-4294967284")
+
+			c, err := s.Combine(t1)
+			So(err, ShouldBeNil)
+			So(c.String(), ShouldEqual, "4294967280-32")
+
+			_, err = s.Combine(t2)
+			So(err, ShouldBeNil)
+			_, err = t1.Combine(t2)
+			So(err, ShouldEqual, ErrNoOverlap)
+
+		})
+
+		Convey("Gap() should take the short way round", func() {
+			t1, _ := sp.Span(0x20, 0x30)
+			g, err := s.Gap(t1)
+			So(err, ShouldBeNil)
+			So(g.String(), ShouldEqual, "16-32")
+
+			g, _ = t1.Gap(s)
+			So(g.String(), ShouldEqual, "16-32")
+
+			t2, _ := sp.Span(0x5, 0x6)
+			_, err = s.Gap(t2)
+			So(err, ShouldEqual, ErrNoGap)
+		})
+
+		Convey("Spans() should split it in two", func() {
+			So(s.Spans(), ShouldResemble, Multispan{{0, 0x10}, {0xFFFFFFF0, 0xFFFFFFFF}})
+
+			u, _ := sp.Span(3, 9)
+			So(u.Spans(), ShouldResemble, Multispan{{3, 9}})
+		})
+	})
+}
@@ -2,6 +2,7 @@ package span
 
 import (
 	"errors"
+	"strconv"
 )
 
 type Span struct {
@@ -11,6 +12,15 @@ type Span struct {
 
 var Zero = Span{0, 0}
 
+// Endpoints for spans with no lower or upper bound
+const (
+	PosInf = int(^uint(0) >> 1)
+	NegInf = -PosInf - 1
+)
+
+// Unbounded covers every integer
+var Unbounded = Span{NegInf, PosInf}
+
 var ErrNoOverlap = errors.New("Spans do not overlap")
 var ErrNoGap = errors.New("No gap between spans")
 
@@ -22,6 +32,16 @@ func NewSpan(start, end int) Span {
 	return Span{Start: start, End: end}
 }
 
+// From is the span of n and everything after it
+func From(n int) Span {
+	return Span{Start: n, End: PosInf}
+}
+
+// UpTo is the span of n and everything before it
+func UpTo(n int) Span {
+	return Span{Start: NegInf, End: n}
+}
+
 func (s Span) Normalize() Span {
 	if s.Start <= s.End {
 		return s
@@ -34,10 +54,34 @@ func (s Span) Contains(n int) bool {
 	return s.Start <= n && s.End >= n
 }
 
+// Len is the number of integers in the span, or PosInf if it is unbounded
+// or holds more integers than an int can count
+func (s Span) Len() int {
+	switch {
+	case !s.IsBounded():
+		return PosInf
+	case s.End < s.Start:
+		return 0
+	}
+
+	// the width can exceed PosInf, but never a uint
+	n := uint(s.End) - uint(s.Start) + 1
+	if n > uint(PosInf) {
+		return PosInf
+	}
+
+	return int(n)
+}
+
 func (s Span) IsPoint() bool {
 	return s.Start == s.End
 }
 
+// IsBounded reports whether neither end of the span is infinite
+func (s Span) IsBounded() bool {
+	return s.Start != NegInf && s.End != PosInf
+}
+
 func (s Span) Overlaps(t Span) bool {
 	return (s.End >= t.Start && s.Start <= t.End) || (t.End >= s.Start && t.Start <= s.End)
 }
@@ -76,6 +120,34 @@ func (s Span) Gap(t Span) (Span, error) {
 	return Span{Start: t.End, End: s.Start}, nil
 }
 
+// String formats the span in the notation read by Parse. Open ends are
+// left blank, so "5-" runs on forever and "*" covers everything, and
+// negative numbers are parenthesized: "(-5)-(-3)".
+func (s Span) String() string {
+	switch {
+	case s == Unbounded:
+		return "*"
+	case s.Start == NegInf:
+		return "-" + itoa(s.End)
+	case s.End == PosInf:
+		return itoa(s.Start) + "-"
+	case s.IsPoint():
+		return itoa(s.Start)
+	}
+
+	return itoa(s.Start) + "-" + itoa(s.End)
+}
+
+// itoa formats a range end, wrapping negative numbers in parentheses so
+// their sign can't be read as a range dash
+func itoa(n int) string {
+	if n < 0 {
+		return "(" + strconv.Itoa(n) + ")"
+	}
+
+	return strconv.Itoa(n)
+}
+
 func max(n1, n2 int) int {
 	if n2 > n1 {
 		return n2
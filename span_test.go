@@ -401,3 +401,116 @@ func TestGap(t *testing.T) {
 		})
 	})
 }
+
+func TestUnbounded(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given spans with open ends", t, func() {
+		after := From(5)
+		before := UpTo(10)
+		tail := From(20)
+		s := Span{12, 15}
+
+		Convey("Contains() should reach the extremes", func() {
+			So(after.Contains(PosInf), ShouldBeTrue)
+			So(after.Contains(4), ShouldBeFalse)
+			So(before.Contains(NegInf), ShouldBeTrue)
+			So(Unbounded.Contains(0), ShouldBeTrue)
+			So(after.IsBounded(), ShouldBeFalse)
+			So(s.IsBounded(), ShouldBeTrue)
+		})
+
+		Convey("Len() should saturate instead of overflowing", func() {
+			So(s.Len(), ShouldEqual, 4)
+			So(after.Len(), ShouldEqual, PosInf)
+			So(Span{-5, PosInf - 1}.Len(), ShouldEqual, PosInf)
+			So(Span{NegInf + 1, -1}.Len(), ShouldEqual, PosInf)
+			So(Span{NegInf + 3, 0}.Len(), ShouldEqual, PosInf-1)
+			So(Span{5, 3}.Len(), ShouldEqual, 0)
+
+			So(Multispan{{NegInf + 1, PosInf - 1}}.Size(), ShouldEqual, PosInf)
+			So(Multispan{{NegInf + 1, -1}, {1, 1}}.Size(), ShouldEqual, PosInf)
+			So(Multispan{{-5, -1}, {1, 1}}.Size(), ShouldEqual, 6)
+
+			_, err := Multispan{{NegInf + 1, PosInf - 1}}.Chunks(10)
+			So(err, ShouldEqual, ErrUnbounded)
+			_, err = Multispan{{NegInf + 1, -1}, {1, 1}}.SplitEven(2)
+			So(err, ShouldEqual, ErrUnbounded)
+			_, err = NewRankIndex(Multispan{{NegInf + 1, -1}, {1, 1}})
+			So(err, ShouldEqual, ErrUnbounded)
+
+			a, b, err := Span{NegInf + 1, PosInf - 1}.Bisect()
+			So(err, ShouldBeNil)
+			So(a, ShouldResemble, Span{NegInf + 1, -1})
+			So(b, ShouldResemble, Span{0, PosInf - 1})
+		})
+
+		Convey("Overlap(), Combine() and Gap() should keep the open ends", func() {
+			o, err := after.Overlap(before)
+			So(err, ShouldBeNil)
+			So(o, ShouldResemble, Span{5, 10})
+
+			c, err := before.Combine(after)
+			So(err, ShouldBeNil)
+			So(c, ShouldResemble, Unbounded)
+
+			g, err := before.Gap(tail)
+			So(err, ShouldBeNil)
+			So(g, ShouldResemble, Span{10, 20})
+
+			_, err = Unbounded.Gap(s)
+			So(err, ShouldEqual, ErrNoGap)
+		})
+
+		Convey("String() should leave the open ends blank", func() {
+			So(after.String(), ShouldEqual, "5-")
+			So(before.String(), ShouldEqual, "-10")
+			So(Unbounded.String(), ShouldEqual, "*")
+			So(s.String(), ShouldEqual, "12-15")
+		})
+	})
+}
+
+func TestStringRoundTrip(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given spans with negative and infinite ends", t, func() {
+		spans := []Span{
+			{0, 0}, {5, 5}, {-5, -5}, {-5, -3}, {-3, 5}, {3, 12},
+			UpTo(0), UpTo(-10), UpTo(10), From(-7), From(0), From(7),
+			{NegInf, NegInf}, {PosInf, PosInf}, {NegInf, -1}, Unbounded,
+		}
+
+		Convey("String() should write negatives in parentheses", func() {
+			So(Span{-5, -5}.String(), ShouldEqual, "(-5)")
+			So(Span{-5, -3}.String(), ShouldEqual, "(-5)-(-3)")
+			So(Span{-3, 5}.String(), ShouldEqual, "(-3)-5")
+			So(UpTo(-10).String(), ShouldEqual, "-(-10)")
+		})
+
+		Convey("Parse() should read back every span String() writes", func() {
+			for _, s := range spans {
+				ms, err := Parse(s.String())
+				So(err, ShouldBeNil)
+				So(ms, ShouldResemble, Multispan{s})
+			}
+
+			ms := Multispan(spans[:6]).Normalize()
+			back, err := Parse(ms.String())
+			So(err, ShouldBeNil)
+			So(back, ShouldResemble, ms)
+
+			strided, err := Parse("(-9)-(-1)/4")
+			So(err, ShouldBeNil)
+			So(strided, ShouldResemble, Multispan{{-9, -9}, {-5, -5}, {-1, -1}})
+			So(strided.StridedString(), ShouldEqual, "(-9)-(-1)/4")
+		})
+
+		Convey("Parse() should reject malformed negatives", func() {
+			for _, s := range []string{"(5)", "(-)", "(--5)", "(-5", "-5)", "((-5))", "(-99999999999999999999)"} {
+				_, err := Parse(s)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
@@ -0,0 +1,177 @@
+package span
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidStep = errors.New("Step must be positive")
+
+// StridedSpan holds every Step-th integer from Start up to and including End.
+// It is only expanded into a Multispan when asked.
+type StridedSpan struct {
+	Start int
+	End   int
+	Step  int
+}
+
+func NewStridedSpan(start, end, step int) (StridedSpan, error) {
+	if step < 1 {
+		return StridedSpan{}, ErrInvalidStep
+	}
+
+	s := NewSpan(start, end)
+
+	if step > 1 && !s.IsBounded() {
+		return StridedSpan{}, ErrUnboundedStride
+	}
+
+	return StridedSpan{Start: s.Start, End: s.End, Step: step}, nil
+}
+
+// Len is the number of integers in the strided span, or PosInf if there
+// are more than an int can count
+func (s StridedSpan) Len() int {
+	if s.Step < 1 || s.End < s.Start {
+		return 0
+	}
+
+	// the width can exceed PosInf, but never a uint
+	n := (uint(s.End)-uint(s.Start))/uint(s.Step) + 1
+	if n == 0 || n > uint(PosInf) {
+		return PosInf
+	}
+
+	return int(n)
+}
+
+// At returns the i-th member, counting from zero
+func (s StridedSpan) At(i int) int {
+	return s.Start + i*s.Step
+}
+
+// Last is the largest member, which may be less than End
+func (s StridedSpan) Last() int {
+	return s.At(s.Len() - 1)
+}
+
+// Span is the smallest span holding every member
+func (s StridedSpan) Span() Span {
+	return Span{Start: s.Start, End: s.Last()}
+}
+
+func (s StridedSpan) Contains(n int) bool {
+	if s.Step < 1 || n < s.Start || n > s.End {
+		return false
+	}
+
+	return (n-s.Start)%s.Step == 0
+}
+
+// Multispan expands the strided span. A step of one gives a single span,
+// anything else gives one point span per member.
+func (s StridedSpan) Multispan() Multispan {
+
+	if s.Step == 1 && s.Start <= s.End {
+		return Multispan{Span{Start: s.Start, End: s.End}}
+	}
+
+	n := s.Len()
+
+	if n <= 0 {
+		return NewMultiSpan(0)
+	}
+
+	ms := NewMultiSpan(n)
+
+	for i := 0; i < n; i++ {
+		m := s.At(i)
+		ms = append(ms, Span{m, m})
+	}
+
+	return ms
+}
+
+// String formats the strided span in the notation read by Parse, leaving
+// the step off when it is one.
+func (s StridedSpan) String() string {
+
+	switch {
+	case s.Step == 1 && s.Start <= s.End:
+		return Span{Start: s.Start, End: s.End}.String()
+	case s.Len() == 0:
+		return ""
+	case s.Len() == 1:
+		return s.Span().String()
+	}
+
+	return s.Span().String() + "/" + strconv.Itoa(s.Step)
+}
+
+// Strides describes a normalized multispan as strided spans. Spans wider
+// than a point are kept as they are; runs of at least three evenly spaced
+// points become a single strided span.
+func (ms Multispan) Strides() []StridedSpan {
+
+	strides := make([]StridedSpan, 0, len(ms))
+
+	i := 0
+	for i < len(ms) {
+
+		s := ms[i]
+
+		if !s.IsPoint() {
+			strides = append(strides, StridedSpan{Start: s.Start, End: s.End, Step: 1})
+			i++
+			continue
+		}
+
+		// extend the run while points keep the same spacing
+		j := i + 1
+		if j < len(ms) && ms[j].IsPoint() && ms[j].Start > s.Start {
+			step := ms[j].Start - s.Start
+			for j+1 < len(ms) && ms[j+1].IsPoint() && ms[j+1].Start-ms[j].Start == step {
+				j++
+			}
+
+			if j-i >= 2 {
+				strides = append(strides, StridedSpan{Start: s.Start, End: ms[j].Start, Step: step})
+				i = j + 1
+				continue
+			}
+		}
+
+		strides = append(strides, StridedSpan{Start: s.Start, End: s.Start, Step: 1})
+		i++
+	}
+
+	return strides
+}
+
+// StridedString formats a normalized multispan using stride notation
+// wherever a run of points allows it.
+func (ms Multispan) StridedString() string {
+
+	strides := ms.Strides()
+	parts := make([]string, len(strides))
+
+	for i, s := range strides {
+		parts[i] = s.String()
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// parity returns the even or odd members of s as a strided span
+func parity(s Span, which string) StridedSpan {
+
+	start := s.Start
+	rem := ((start % 2) + 2) % 2
+
+	if (which == "even") != (rem == 0) {
+		start++
+	}
+
+	return StridedSpan{Start: start, End: s.End, Step: 2}
+}
@@ -0,0 +1,74 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestStridedSpan(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a strided span from 1 to 20 by 3", t, func() {
+		s, err := NewStridedSpan(20, 1, 3)
+
+		Convey("It should be normalized and know its members without expanding", func() {
+			So(err, ShouldBeNil)
+			So(s, ShouldResemble, StridedSpan{1, 20, 3})
+			So(s.Len(), ShouldEqual, 7)
+			So(StridedSpan{NegInf + 1, PosInf - 1, 2}.Len(), ShouldEqual, PosInf)
+			So(StridedSpan{-4, 4, 2}.Len(), ShouldEqual, 5)
+			So(s.Last(), ShouldEqual, 19)
+			So(s.Span(), ShouldResemble, Span{1, 19})
+
+			So(s.Contains(1), ShouldBeTrue)
+			So(s.Contains(4), ShouldBeTrue)
+			So(s.Contains(19), ShouldBeTrue)
+			So(s.Contains(2), ShouldBeFalse)
+			So(s.Contains(20), ShouldBeFalse)
+			So(s.Contains(22), ShouldBeFalse)
+			So(s.Contains(-2), ShouldBeFalse)
+		})
+
+		Convey("When expanded", func() {
+			ms := s.Multispan()
+
+			Convey("Each member should be a point span", func() {
+				So(ms.Len(), ShouldEqual, 7)
+				So(ms.Get(0), ShouldResemble, Span{1, 1})
+				So(ms.Get(6), ShouldResemble, Span{19, 19})
+			})
+		})
+
+		Convey("When formatted", func() {
+			So(s.String(), ShouldEqual, "1-19/3")
+		})
+	})
+
+	Convey("Given degenerate strided spans", t, func() {
+		_, err := NewStridedSpan(1, 10, 0)
+		unit := StridedSpan{1, 10, 1}
+		point := StridedSpan{5, 6, 4}
+
+		Convey("They should be rejected or collapse to plain spans", func() {
+			So(err, ShouldEqual, ErrInvalidStep)
+			So(unit.Multispan(), ShouldResemble, Multispan{Span{1, 10}})
+			So(unit.String(), ShouldEqual, "1-10")
+			So(point.String(), ShouldEqual, "5")
+		})
+	})
+}
+
+func TestStrides(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a normalized multispan of points and ranges", t, func() {
+		ms, _ := Parse("0-59/15,100-110,200,203")
+
+		Convey("When formatted with StridedString()", func() {
+			s := ms.StridedString()
+
+			Convey("Evenly spaced points should be written as strides", func() {
+				So(ms.String(), ShouldEqual, "0,15,30,45,100-110,200,203")
+				So(s, ShouldEqual, "0-45/15,100-110,200,203")
+			})
+		})
+	})
+}
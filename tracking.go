@@ -0,0 +1,115 @@
+package span
+
+import (
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+// TrackingWriterAt wraps an io.WriterAt and records which byte ranges have
+// been written, for downloads that arrive out of order
+type TrackingWriterAt struct {
+	w      io.WriterAt
+	target Span
+
+	mu      sync.Mutex
+	written Multispan // normalized, touching spans merged
+	done    chan struct{}
+}
+
+// NewTrackingWriterAt tracks writes to w. Done is closed once every byte
+// of target has been written.
+func NewTrackingWriterAt(w io.WriterAt, target Span) *TrackingWriterAt {
+	return &TrackingWriterAt{
+		w:       w,
+		target:  target.Normalize(),
+		written: NewMultiSpan(0),
+		done:    make(chan struct{}),
+	}
+}
+
+// WriteAt writes through to the wrapped writer and records the bytes it
+// reports as written, even when it also returns an error
+func (t *TrackingWriterAt) WriteAt(p []byte, off int64) (int, error) {
+
+	n, err := t.w.WriteAt(p, off)
+
+	if n > 0 {
+		t.record(Multispan{{Start: int(off), End: int(off) + n - 1}})
+	}
+
+	return n, err
+}
+
+// Written is the normalized multispan of bytes written so far
+func (t *TrackingWriterAt) Written() Multispan {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append(Multispan{}, t.written...)
+}
+
+// Missing lists the bytes from 0 to total-1 not yet written
+func (t *TrackingWriterAt) Missing(total int64) Multispan {
+
+	if total <= 0 {
+		return NewMultiSpan(0)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return Multispan{{Start: 0, End: int(total) - 1}}.Subtract(t.written)
+}
+
+// Done is closed once the target is fully written
+func (t *TrackingWriterAt) Done() <-chan struct{} {
+	return t.done
+}
+
+// SaveProgress writes the ranges written so far in the notation read by
+// Parse
+func (t *TrackingWriterAt) SaveProgress(w io.Writer) error {
+
+	_, err := io.WriteString(w, t.Written().String())
+
+	return err
+}
+
+// LoadProgress reads ranges saved by SaveProgress and records them as
+// written, as when resuming a download
+func (t *TrackingWriterAt) LoadProgress(r io.Reader) error {
+
+	text, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	ms, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	t.record(ms)
+
+	return nil
+}
+
+func (t *TrackingWriterAt) record(ms Multispan) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.written = t.written.Union(ms).NormalizeWithin(1)
+
+	select {
+	case <-t.done:
+		return
+	default:
+	}
+
+	if len(Multispan{t.target}.Subtract(t.written)) == 0 {
+		close(t.done)
+	}
+}
@@ -0,0 +1,85 @@
+package span
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+import . "github.com/smartystreets/goconvey/convey"
+
+// memWriterAt is an in-memory io.WriterAt that can be told to fail
+type memWriterAt struct {
+	buf   []byte
+	limit int
+}
+
+func (m *memWriterAt) WriteAt(p []byte, off int64) (int, error) {
+	n := copy(m.buf[off:], p)
+	if m.limit > 0 && n > m.limit {
+		return m.limit, errors.New("short write")
+	}
+	return n, nil
+}
+
+func TestTrackingWriterAt(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a tracked 20 byte download", t, func() {
+		mem := &memWriterAt{buf: make([]byte, 20)}
+		tw := NewTrackingWriterAt(mem, Span{0, 19})
+
+		Convey("Out of order writes should be recorded", func() {
+			tw.WriteAt([]byte("fghij"), 5)
+			tw.WriteAt([]byte("pqrst"), 15)
+			tw.WriteAt([]byte("abcde"), 0)
+
+			So(tw.Written(), ShouldResemble, Multispan{{0, 9}, {15, 19}})
+			So(tw.Missing(20), ShouldResemble, Multispan{{10, 14}})
+			So(tw.Missing(25), ShouldResemble, Multispan{{10, 14}, {20, 24}})
+
+			early := false
+			select {
+			case <-tw.Done():
+				early = true
+			default:
+			}
+			So(early, ShouldBeFalse)
+
+			Convey("And the last chunk should close Done()", func() {
+				tw.WriteAt([]byte("klmno"), 10)
+
+				<-tw.Done()
+				So(string(mem.buf), ShouldEqual, "abcdefghijklmnopqrst")
+				So(tw.Missing(20), ShouldBeEmpty)
+			})
+		})
+
+		Convey("Short writes should only record what was written", func() {
+			mem.limit = 2
+			n, err := tw.WriteAt([]byte("abcde"), 0)
+
+			So(n, ShouldEqual, 2)
+			So(err, ShouldNotBeNil)
+			So(tw.Written(), ShouldResemble, Multispan{{0, 1}})
+		})
+
+		Convey("Progress should survive a save and load", func() {
+			tw.WriteAt([]byte("abcde"), 0)
+			tw.WriteAt([]byte("pqrst"), 15)
+
+			var saved bytes.Buffer
+			So(tw.SaveProgress(&saved), ShouldBeNil)
+			So(saved.String(), ShouldEqual, "0-4,15-19")
+
+			resumed := NewTrackingWriterAt(mem, Span{0, 19})
+			So(resumed.LoadProgress(&saved), ShouldBeNil)
+			So(resumed.Missing(20), ShouldResemble, Multispan{{5, 14}})
+
+			resumed.WriteAt(make([]byte, 10), 5)
+			<-resumed.Done()
+
+			So(resumed.LoadProgress(strings.NewReader("x")), ShouldEqual, ErrSyntax)
+		})
+	})
+}
@@ -0,0 +1,126 @@
+package span
+
+// Transforms leave infinite ends where they are, and finite ends that
+// would overflow stop at PosInf or NegInf.
+
+// Shift moves the span by d
+func (s Span) Shift(d int) Span {
+	return Span{Start: shift(s.Start, d), End: shift(s.End, d)}
+}
+
+// Scale maps the span to the integers covered when each member becomes a
+// block of k integers, so the end lands on the last integer of its block:
+// Span{2, 3}.Scale(10) is Span{20, 39}.
+func (s Span) Scale(k int) (Span, error) {
+	if k < 1 {
+		return Zero, ErrInvalidSize
+	}
+
+	start, end := s.Start, s.End
+
+	if start != NegInf && start != PosInf {
+		start = mul(start, k)
+	}
+
+	if end != NegInf && end != PosInf {
+		if end = mul(end+1, k); end != NegInf && end != PosInf {
+			end--
+		}
+	}
+
+	return Span{Start: start, End: end}, nil
+}
+
+// Clamp is the part of the span inside bounds
+func (s Span) Clamp(bounds Span) (Span, error) {
+	return s.Overlap(bounds)
+}
+
+// MapMonotonic applies f to both ends and normalizes the result. The
+// caller must make sure f is monotonic, increasing or decreasing, over the
+// span; otherwise the result is not the image of the span.
+func (s Span) MapMonotonic(f func(int) int) Span {
+	return NewSpan(mapEnd(s.Start, f), mapEnd(s.End, f))
+}
+
+func (ms Multispan) Shift(d int) Multispan {
+
+	out := make(Multispan, len(ms))
+
+	for i, s := range ms {
+		out[i] = s.Shift(d)
+	}
+
+	return out
+}
+
+func (ms Multispan) Scale(k int) (Multispan, error) {
+
+	out := make(Multispan, len(ms))
+
+	for i, s := range ms {
+
+		t, err := s.Scale(k)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = t
+	}
+
+	return out, nil
+}
+
+// Clamp drops everything outside bounds. The multispan must be normalized.
+func (ms Multispan) Clamp(bounds Span) Multispan {
+	return ms.Intersect(Multispan{bounds.Normalize()})
+}
+
+// MapMonotonic applies f to the ends of every span, then normalizes, so
+// spans that f reorders or runs together are merged. As with
+// Span.MapMonotonic, f must be monotonic over each span.
+func (ms Multispan) MapMonotonic(f func(int) int) Multispan {
+
+	out := make(Multispan, len(ms))
+
+	for i, s := range ms {
+		out[i] = s.MapMonotonic(f)
+	}
+
+	return out.Normalize()
+}
+
+func shift(n, d int) int {
+	if n == NegInf || n == PosInf {
+		return n
+	}
+
+	switch {
+	case d > 0 && n > PosInf-d:
+		return PosInf
+	case d < 0 && n < NegInf-d:
+		return NegInf
+	}
+
+	return n + d
+}
+
+// mul is n*k for positive k, stopping at PosInf or NegInf
+func mul(n, k int) int {
+	switch {
+	case n > PosInf/k:
+		return PosInf
+	case n < NegInf/k:
+		return NegInf
+	}
+
+	return n * k
+}
+
+func mapEnd(n int, f func(int) int) int {
+	if n == NegInf || n == PosInf {
+		return n
+	}
+
+	return f(n)
+}
@@ -0,0 +1,76 @@
+package span
+
+import "testing"
+import . "github.com/smartystreets/goconvey/convey"
+
+func TestShiftScale(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given 1-based page spans", t, func() {
+		ms := Multispan{{1, 3}, {7, 7}, From(10)}
+
+		Convey("Shift() should move every span but not the open end", func() {
+			So(ms.Shift(-1), ShouldResemble, Multispan{{0, 2}, {6, 6}, From(9)})
+			So(Span{2, 5}.Shift(3), ShouldResemble, Span{5, 8})
+		})
+
+		Convey("Shift() and Scale() should stop at the infinities", func() {
+			So(Span{PosInf - 2, PosInf - 1}.Shift(5), ShouldResemble, Span{PosInf, PosInf})
+			So(Span{NegInf + 1, 0}.Shift(-5), ShouldResemble, Span{NegInf, -5})
+
+			s, err := Span{PosInf / 2, PosInf/2 + 1}.Scale(4)
+			So(err, ShouldBeNil)
+			So(s, ShouldResemble, Span{PosInf, PosInf})
+
+			s, err = Span{NegInf / 2, 0}.Scale(3)
+			So(err, ShouldBeNil)
+			So(s, ShouldResemble, Span{NegInf, 2})
+		})
+
+		Convey("Scale() should cover whole blocks", func() {
+			s, err := Span{2, 3}.Scale(10)
+			So(err, ShouldBeNil)
+			So(s, ShouldResemble, Span{20, 39})
+
+			s, err = Span{-1, -1}.Scale(4)
+			So(err, ShouldBeNil)
+			So(s, ShouldResemble, Span{-4, -1})
+
+			scaled, err := ms.Scale(2)
+			So(err, ShouldBeNil)
+			So(scaled, ShouldResemble, Multispan{{2, 7}, {14, 15}, From(20)})
+
+			_, err = ms.Scale(0)
+			So(err, ShouldEqual, ErrInvalidSize)
+		})
+
+		Convey("Clamp() should cut to the bounds", func() {
+			So(ms.Clamp(Span{2, 12}), ShouldResemble, Multispan{{2, 3}, {7, 7}, {10, 12}})
+
+			c, err := Span{1, 3}.Clamp(Span{2, 8})
+			So(err, ShouldBeNil)
+			So(c, ShouldResemble, Span{2, 3})
+
+			_, err = Span{1, 3}.Clamp(Span{5, 8})
+			So(err, ShouldEqual, ErrNoOverlap)
+		})
+	})
+}
+
+func TestMap(t *testing.T) {
+
+	// Only pass t into top-level Convey calls
+	Convey("Given a normalized multispan", t, func() {
+		ms := Multispan{{1, 3}, {5, 6}, {10, 12}}
+
+		Convey("MapMonotonic() with a decreasing function should flip and reorder spans", func() {
+			So(Span{1, 3}.MapMonotonic(func(n int) int { return -n }), ShouldResemble, Span{-3, -1})
+			So(ms.MapMonotonic(func(n int) int { return 20 - n }), ShouldResemble, Multispan{{8, 10}, {14, 15}, {17, 19}})
+		})
+
+		Convey("MapMonotonic() that runs spans together should merge them", func() {
+			third := func(n int) int { return n / 3 }
+			So(ms.MapMonotonic(third), ShouldResemble, Multispan{{0, 2}, {3, 4}})
+		})
+	})
+}